@@ -0,0 +1,197 @@
+package chargify
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+)
+
+var errMissingCredentials = errors.New("chargify: Subdomain and APIKey are required")
+
+// Logger is the minimal logging interface the Client writes diagnostic
+// output to. *log.Logger satisfies this interface.
+type Logger interface {
+	Printf(format string, v ...interface{})
+}
+
+// RetryPolicy controls how the Client retries failed mutating requests.
+type RetryPolicy struct {
+	MaxAttempts int           // Maximum number of attempts, including the first. Zero disables retries.
+	BaseDelay   time.Duration // Base delay used to compute exponential backoff
+	MaxDelay    time.Duration // Upper bound on the computed backoff delay
+}
+
+// DefaultRetryPolicy is used when a Config does not specify one.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 3,
+	BaseDelay:   500 * time.Millisecond,
+	MaxDelay:    5 * time.Second,
+}
+
+// Config carries everything needed to construct a Client. Subdomain and
+// APIKey are required; everything else has a sensible default.
+type Config struct {
+	Subdomain string // The Chargify subdomain, e.g. "acme" for acme.chargify.com
+	APIKey    string // The Chargify API key used as the HTTP Basic Auth username
+
+	HTTPClient *http.Client  // Underlying HTTP client. Defaults to a new http.Client if nil
+	BaseURL    string        // Overrides the default "https://{subdomain}.chargify.com" base URL
+	Timeout    time.Duration // Per-request timeout. Defaults to 30s if zero
+
+	RetryPolicy *RetryPolicy // Retry behavior for mutating requests. Defaults to DefaultRetryPolicy if nil; pass &RetryPolicy{} (or any policy with MaxAttempts: 0) to disable retries
+	Logger      Logger       // Optional logger for diagnostic output, e.g. generated idempotency keys
+
+	// Transport, when set, is used as the http.RoundTripper for the
+	// underlying HTTP client, letting callers inject tracing or metrics.
+	// Ignored if HTTPClient is also set.
+	Transport http.RoundTripper
+}
+
+// Client is a configured handle to the Chargify API. Unlike the historical
+// package-level functions, a Client reads no process-level state, so
+// multiple Clients (e.g. pointed at different sandboxes) can be used
+// concurrently, and every method accepts a context.Context for
+// cancellation and deadlines.
+type Client struct {
+	subdomain string
+	apiKey    string
+	baseURL   string
+	timeout   time.Duration
+	http      *http.Client
+	retry     RetryPolicy
+	logger    Logger
+}
+
+// NewClient builds a Client from cfg. It returns an error if Subdomain or
+// APIKey are missing.
+func NewClient(cfg Config) (*Client, error) {
+	if cfg.Subdomain == "" || cfg.APIKey == "" {
+		return nil, errMissingCredentials
+	}
+
+	httpClient := cfg.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{}
+		if cfg.Transport != nil {
+			httpClient.Transport = cfg.Transport
+		}
+	}
+
+	timeout := cfg.Timeout
+	if timeout == 0 {
+		timeout = 30 * time.Second
+	}
+
+	retry := DefaultRetryPolicy
+	if cfg.RetryPolicy != nil {
+		retry = *cfg.RetryPolicy
+	}
+
+	baseURL := cfg.BaseURL
+	if baseURL == "" {
+		baseURL = "https://" + cfg.Subdomain + ".chargify.com"
+	}
+
+	return &Client{
+		subdomain: cfg.Subdomain,
+		apiKey:    cfg.APIKey,
+		baseURL:   baseURL,
+		timeout:   timeout,
+		http:      httpClient,
+		retry:     retry,
+		logger:    cfg.Logger,
+	}, nil
+}
+
+// DefaultClient is used by the deprecated package-level API functions
+// (CreateCustomer, GetCustomers, CreateProduct, ...). It is nil until a
+// caller sets it, via SetDefaultClient or by assigning it directly;
+// NewClient does not set it. New code should call NewClient directly and use the
+// returned Client's methods instead of the package-level functions, which
+// will be removed in a future release.
+var DefaultClient *Client
+
+// SetDefaultClient configures DefaultClient, enabling the deprecated
+// package-level functions for callers that have not yet migrated to the
+// Client API.
+func SetDefaultClient(c *Client) {
+	DefaultClient = c
+}
+
+// errDefaultClientNotConfigured is returned by the deprecated package-level
+// functions when DefaultClient is nil, instead of letting them panic on a
+// nil pointer dereference.
+var errDefaultClientNotConfigured = errors.New("chargify: DefaultClient is not configured; call SetDefaultClient before using the deprecated package-level functions")
+
+// defaultClient returns DefaultClient, or errDefaultClientNotConfigured if
+// the package has not been configured via SetDefaultClient.
+func defaultClient() (*Client, error) {
+	if DefaultClient == nil {
+		return nil, errDefaultClientNotConfigured
+	}
+	return DefaultClient, nil
+}
+
+// makeCall issues an API request against this Client's configured
+// subdomain, respecting ctx for cancellation/deadlines. It replaces the
+// historical process-level makeCall function so that request behavior
+// (base URL, HTTP client, timeout) is scoped to the Client instance
+// rather than read from package globals.
+//
+// Mutating requests (POST/PUT/PATCH/DELETE) are retried with exponential
+// backoff and jitter on 429 and 5xx responses, honoring a Retry-After
+// header when present, up to c.retry.MaxAttempts. Every mutating request
+// carries an Idempotency-Key header so retries - by this method or by a
+// caller's own at-least-once job queue - do not create duplicate
+// customers, subscriptions, or charges. WithIdempotencyKey lets a caller
+// supply their own key; otherwise one is generated and logged.
+//
+// If a mutating request ultimately fails, the returned error is an
+// *IdempotencyKeyError wrapping the underlying cause, so the key used is
+// not lost even though the call itself failed.
+func (c *Client) makeCall(ctx context.Context, endpoint endpointSpec, body interface{}, params *map[string]string, opts ...RequestOption) (*apiResponse, error) {
+	resolved := resolveRequestOptions(opts)
+
+	mutating := isMutatingMethod(endpoint.Method())
+	if mutating && resolved.idempotencyKey == "" {
+		key, err := newIdempotencyKey()
+		if err != nil {
+			return nil, err
+		}
+		resolved.idempotencyKey = key
+		if c.logger != nil {
+			c.logger.Printf("chargify: generated idempotency key %s for %s", key, endpoint.Method())
+		}
+	}
+
+	maxAttempts := 1
+	if mutating && c.retry.MaxAttempts > maxAttempts {
+		maxAttempts = c.retry.MaxAttempts
+	}
+
+	var lastErr error
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		ret, err := doAPICall(ctx, c, endpoint, body, params, resolved.idempotencyKey)
+		if err == nil {
+			if ret != nil {
+				ret.IdempotencyKey = resolved.idempotencyKey
+			}
+			return ret, nil
+		}
+
+		lastErr = err
+		statusErr, ok := err.(*apiStatusError)
+		if !ok || !isRetryableStatus(statusErr.StatusCode) || attempt == maxAttempts {
+			return nil, wrapIdempotencyKeyError(mutating, resolved.idempotencyKey, err)
+		}
+
+		delay := backoffDelay(c.retry, attempt, parseRetryAfter(statusErr.RetryAfter))
+		select {
+		case <-ctx.Done():
+			return nil, wrapIdempotencyKeyError(mutating, resolved.idempotencyKey, ctx.Err())
+		case <-time.After(delay):
+		}
+	}
+	return nil, wrapIdempotencyKeyError(mutating, resolved.idempotencyKey, lastErr)
+}
@@ -0,0 +1,245 @@
+package chargify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ComponentKind identifies which of Chargify's four billing models a
+// Component uses.
+type ComponentKind string
+
+var (
+	// ComponentKindMetered bills for usage recorded via RecordUsage, with
+	// no allocation step.
+	ComponentKindMetered ComponentKind = "metered_component"
+	// ComponentKindQuantity bills for a quantity the customer is
+	// allocated via AllocateComponent.
+	ComponentKindQuantity ComponentKind = "quantity_based_component"
+	// ComponentKindOnOff bills a flat amount while the component is
+	// toggled on for a subscription.
+	ComponentKindOnOff ComponentKind = "on_off_component"
+	// ComponentKindPrepaid bills for usage drawn down against a prepaid
+	// balance purchased in advance.
+	ComponentKindPrepaid ComponentKind = "prepaid_usage_component"
+)
+
+// PricingScheme identifies how a Component's Prices tiers are evaluated.
+type PricingScheme string
+
+var (
+	// PricingSchemePerUnit charges UnitPriceInCents for every unit.
+	PricingSchemePerUnit PricingScheme = "per_unit"
+	// PricingSchemeVolume charges the whole quantity at the unit price of
+	// the tier the quantity falls into.
+	PricingSchemeVolume PricingScheme = "volume"
+	// PricingSchemeTiered charges each tier's portion of the quantity at
+	// that tier's unit price.
+	PricingSchemeTiered PricingScheme = "tiered"
+	// PricingSchemeStairstep charges a single flat price for the tier the
+	// whole quantity falls into.
+	PricingSchemeStairstep PricingScheme = "stairstep"
+)
+
+// ComponentPrice is one tier of a Component's pricing schedule. EndingQuantity
+// is nil for the final, open-ended tier.
+type ComponentPrice struct {
+	ID               int64  `json:"id"`
+	StartingQuantity int    `json:"starting_quantity"`             // The first unit, inclusive, this tier applies to
+	EndingQuantity   *int   `json:"ending_quantity,omitempty"`     // The last unit, inclusive, this tier applies to. Nil means unbounded
+	UnitPriceInCents *int   `json:"unit_price_in_cents,omitempty"` // The per-unit price for this tier, in integer cents. Deprecated: prefer UnitPrice, which carries sub-cent precision
+	UnitPrice        *Money `json:"unit_price,omitempty"`          // The per-unit price for this tier with sub-cent precision, e.g. "0.0025". Mirrors UnitPriceInCents during the deprecation window
+}
+
+// Component represents a metered, quantity-based, on/off, or prepaid usage
+// billing component within a product family.
+type Component struct {
+	ID            int64            `json:"id"`
+	Name          string           `json:"name"`                     // The component name
+	Kind          ComponentKind    `json:"kind"`                     // Which of the four Chargify billing models this component uses
+	UnitName      string           `json:"unit_name"`                // The singular name of the unit being measured, e.g. "API call"
+	PricingScheme PricingScheme    `json:"pricing_scheme"`           // How Prices is evaluated against a given quantity
+	Prices        []ComponentPrice `json:"prices"`                   // The tiered pricing schedule
+	TaxableFlag   bool             `json:"taxable"`                  // Whether usage of this component is taxable
+	PricePointID  int64            `json:"price_point_id,omitempty"` // The price point this component's pricing is scoped to, if any
+}
+
+// validate checks the fields common to every component kind.
+func (comp *Component) validate() error {
+	if comp.Name == "" || comp.UnitName == "" {
+		return errors.New("name and unit name are required")
+	}
+	if comp.Kind == "" {
+		return errors.New("kind is required")
+	}
+	if len(comp.Prices) == 0 {
+		return errors.New("at least one price tier is required")
+	}
+	return nil
+}
+
+// CreateComponent creates a new component within a product family.
+func (c *Client) CreateComponent(ctx context.Context, familyID int64, comp *Component) error {
+	if err := comp.validate(); err != nil {
+		return err
+	}
+	body := map[string]Component{
+		"component": *comp,
+	}
+
+	ret, err := c.makeCall(ctx, endpoints[endpointComponentCreate], body, &map[string]string{
+		"familyID": fmt.Sprintf("%d", familyID),
+	})
+	if err != nil {
+		return err
+	}
+	apiBody, bodyOK := ret.Body.(map[string]interface{})
+	if !bodyOK {
+		return errors.New("could not understand server response")
+	}
+	return decode(apiBody["component"], comp)
+}
+
+// ListComponents lists every component defined on a product family,
+// honoring opts. Callers expecting more than one page of results should
+// use Client.Components instead, which iterates transparently.
+func (c *Client) ListComponents(ctx context.Context, familyID int64, opts *ListOptions) ([]Component, error) {
+	components := []Component{}
+	params := opts.params()
+	params["familyID"] = fmt.Sprintf("%d", familyID)
+	ret, err := c.makeCall(ctx, endpoints[endpointComponentList], nil, &params)
+	if err != nil {
+		return nil, err
+	}
+
+	temp, ok := ret.Body.([]interface{})
+	if !ok {
+		return nil, errors.New("could not understand server response")
+	}
+	for i := range temp {
+		entry, ok := temp[i].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		comp := Component{}
+		if err := decode(entry["component"], &comp); err == nil {
+			components = append(components, comp)
+		}
+	}
+	return components, nil
+}
+
+// AllocateComponent sets the allocated quantity of a quantity-based or
+// on/off component for a subscription. memo is recorded against the
+// resulting allocation for audit purposes.
+func (c *Client) AllocateComponent(ctx context.Context, subscriptionID, componentID int64, quantity int, memo string) error {
+	body := map[string]map[string]interface{}{
+		"allocation": {
+			"quantity": quantity,
+			"memo":     memo,
+		},
+	}
+	_, err := c.makeCall(ctx, endpoints[endpointComponentAllocate], body, &map[string]string{
+		"subscriptionID": fmt.Sprintf("%d", subscriptionID),
+		"componentID":    fmt.Sprintf("%d", componentID),
+	})
+	return err
+}
+
+// AllocationPreview describes the prorated cost impact of changing a
+// component's allocation, without committing the change.
+type AllocationPreview struct {
+	ProrationInCents int   `json:"proration_in_cents"`  // The prorated charge or credit for the remainder of the current period, in integer cents. Deprecated: prefer Proration, which carries sub-cent precision
+	Proration        Money `json:"proration,omitempty"` // The prorated charge or credit for the remainder of the current period. Mirrors ProrationInCents during the deprecation window
+	NewTotalInCents  int   `json:"new_total_in_cents"`  // The subscription's total after the allocation change, in integer cents. Deprecated: prefer NewTotal, which carries sub-cent precision
+	NewTotal         Money `json:"new_total,omitempty"` // The subscription's total after the allocation change. Mirrors NewTotalInCents during the deprecation window
+}
+
+// PreviewAllocation returns the prorated cost impact of allocating
+// quantity units of componentID to subscriptionID, without committing the
+// change. This is intended for UI-side upgrade/downgrade confirmation
+// dialogs.
+func (c *Client) PreviewAllocation(ctx context.Context, subscriptionID, componentID int64, quantity int) (*AllocationPreview, error) {
+	preview := &AllocationPreview{}
+	ret, err := c.makeCall(ctx, endpoints[endpointComponentAllocationPreview], nil, &map[string]string{
+		"subscriptionID": fmt.Sprintf("%d", subscriptionID),
+		"componentID":    fmt.Sprintf("%d", componentID),
+		"quantity":       fmt.Sprintf("%d", quantity),
+	})
+	if err != nil {
+		return nil, err
+	}
+	apiBody, bodyOK := ret.Body.(map[string]interface{})
+	if !bodyOK {
+		return nil, errors.New("could not understand server response")
+	}
+	err = decode(apiBody["allocation_preview"], preview)
+	return preview, err
+}
+
+// Usage is a single recorded unit of consumption against a metered
+// component.
+type Usage struct {
+	Quantity  int        `json:"quantity"`            // The amount of usage to record
+	Memo      string     `json:"memo,omitempty"`      // A note recorded against the usage for audit purposes
+	Timestamp *time.Time `json:"timestamp,omitempty"` // When the usage occurred. Defaults to now on the server if omitted
+}
+
+// RecordUsage records a single Usage against a metered component on a
+// subscription.
+func (c *Client) RecordUsage(ctx context.Context, subscriptionID, componentID int64, u Usage) error {
+	if u.Quantity <= 0 {
+		return errors.New("quantity must be greater than 0")
+	}
+	body := map[string]Usage{
+		"usage": u,
+	}
+	_, err := c.makeCall(ctx, endpoints[endpointComponentRecordUsage], body, &map[string]string{
+		"subscriptionID": fmt.Sprintf("%d", subscriptionID),
+		"componentID":    fmt.Sprintf("%d", componentID),
+	})
+	return err
+}
+
+// BulkRecordUsage records several Usage entries against a metered
+// component in a single request.
+func (c *Client) BulkRecordUsage(ctx context.Context, subscriptionID, componentID int64, usages []Usage) error {
+	if len(usages) == 0 {
+		return errors.New("at least one usage entry is required")
+	}
+	body := map[string][]Usage{
+		"usages": usages,
+	}
+	_, err := c.makeCall(ctx, endpoints[endpointComponentBulkRecordUsage], body, &map[string]string{
+		"subscriptionID": fmt.Sprintf("%d", subscriptionID),
+		"componentID":    fmt.Sprintf("%d", componentID),
+	})
+	return err
+}
+
+// Event is a single occurrence posted to an events-based billing segment
+// stream, identified by its stream handle.
+type Event struct {
+	ID         string                 `json:"id,omitempty"`         // A caller-supplied unique id used to de-duplicate retried events
+	EventType  string                 `json:"event_type"`           // The name of the event as configured on the events-based component
+	CustomerID int64                  `json:"customer_id"`          // The customer the event is attributed to
+	Timestamp  *time.Time             `json:"timestamp,omitempty"`  // When the event occurred. Defaults to now on the server if omitted
+	Properties map[string]interface{} `json:"properties,omitempty"` // Arbitrary properties used to compute the event's billable quantity
+}
+
+// RecordEvent ingests a single Event into the events-based billing stream
+// identified by streamHandle.
+func (c *Client) RecordEvent(ctx context.Context, streamHandle string, ev Event) error {
+	if ev.EventType == "" {
+		return errors.New("event type is required")
+	}
+	body := map[string]Event{
+		"event": ev,
+	}
+	_, err := c.makeCall(ctx, endpoints[endpointEventRecord], body, &map[string]string{
+		"streamHandle": streamHandle,
+	})
+	return err
+}
@@ -0,0 +1,254 @@
+package chargify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/shopspring/decimal"
+)
+
+// CouponRestriction scopes a Coupon to a subset of a product family's
+// products or components, rather than applying to all of them.
+type CouponRestriction struct {
+	ProductID   int64 `json:"product_id,omitempty"`   // The restricted product's id, if this restriction targets a product
+	ComponentID int64 `json:"component_id,omitempty"` // The restricted component's id, if this restriction targets a component
+}
+
+// Coupon represents either a percentage-based or flat-amount discount that
+// can be applied to subscriptions within a product family. Exactly one of
+// Percentage or AmountInCents is set; use NewPercentageCoupon or
+// NewFlatAmountCoupon to construct one safely.
+type Coupon struct {
+	ID                          int64               `json:"id"`
+	Code                        string              `json:"code"`                             // The coupon code a customer enters to redeem it
+	Name                        string              `json:"name"`                             // The coupon name
+	Description                 string              `json:"description,omitempty"`            // The coupon description
+	Percentage                  *decimal.Decimal    `json:"percentage,omitempty"`             // The percentage discount, e.g. 10.5 for 10.5%. Mutually exclusive with AmountInCents
+	AmountInCents               *int                `json:"amount_in_cents,omitempty"`        // The flat discount, in integer cents. Deprecated: prefer Amount, which carries sub-cent precision. Mutually exclusive with Percentage
+	Amount                      *Money              `json:"amount,omitempty"`                 // The flat discount with sub-cent precision. Mirrors AmountInCents during the deprecation window
+	AllowNegativeBalance        bool                `json:"allow_negative_balance"`           // Whether the discount may push the subscription balance negative, generating a credit
+	Recurring                   bool                `json:"recurring"`                        // Whether the discount applies to every billing period or just the first
+	EndDate                     string              `json:"end_date,omitempty"`               // Timestamp after which the coupon can no longer be redeemed
+	DurationPeriodCount         *int                `json:"duration_period_count,omitempty"`  // Number of billing periods the discount applies for, when Recurring is true
+	Stackable                   bool                `json:"stackable"`                        // Whether this coupon can be combined with others on the same subscription
+	CompoundingStrategy         string              `json:"compounding_strategy,omitempty"`   // How this coupon compounds with other stacked coupons, e.g. "full-price" or "compound"
+	ExcludeMidPeriodAllocations bool                `json:"exclude_mid_period_allocations"`   // Whether mid-period component allocations are excluded from the discount
+	ApplyOnCancelAtEndOfPeriod  bool                `json:"apply_on_cancel_at_end_of_period"` // Whether the discount still applies for the remainder of the period on a cancel-at-end-of-period subscription
+	ProductFamilyID             int64               `json:"product_family_id"`                // The product family this coupon belongs to
+	Restrictions                []CouponRestriction `json:"restrictions,omitempty"`           // Limits the coupon's eligibility to specific products/components. Empty means the coupon applies family-wide
+}
+
+// NewPercentageCoupon builds a Coupon that discounts by percentage. It
+// returns an error if percentage is not positive.
+func NewPercentageCoupon(productFamilyID int64, code, name string, percentage decimal.Decimal) (*Coupon, error) {
+	if percentage.Sign() <= 0 {
+		return nil, errors.New("percentage must be greater than 0")
+	}
+	return &Coupon{
+		ProductFamilyID: productFamilyID,
+		Code:            code,
+		Name:            name,
+		Percentage:      &percentage,
+	}, nil
+}
+
+// NewFlatAmountCoupon builds a Coupon that discounts by a flat amount in
+// cents. It returns an error if amountInCents is not positive.
+func NewFlatAmountCoupon(productFamilyID int64, code, name string, amountInCents int) (*Coupon, error) {
+	if amountInCents <= 0 {
+		return nil, errors.New("amount in cents must be greater than 0")
+	}
+	amount := NewMoneyFromCents(amountInCents, "")
+	return &Coupon{
+		ProductFamilyID: productFamilyID,
+		Code:            code,
+		Name:            name,
+		AmountInCents:   &amountInCents,
+		Amount:          &amount,
+	}, nil
+}
+
+// validate enforces that exactly one of Percentage or AmountInCents is set.
+func (coupon *Coupon) validate() error {
+	if coupon.Code == "" || coupon.Name == "" {
+		return errors.New("code and name are required")
+	}
+	if (coupon.Percentage == nil) == (coupon.AmountInCents == nil) {
+		return errors.New("exactly one of percentage or amount in cents must be set")
+	}
+	return nil
+}
+
+// CreateCoupon creates coupon, which must have been built with
+// NewPercentageCoupon or NewFlatAmountCoupon.
+func (c *Client) CreateCoupon(ctx context.Context, coupon *Coupon) error {
+	if err := coupon.validate(); err != nil {
+		return err
+	}
+	body := map[string]Coupon{
+		"coupon": *coupon,
+	}
+
+	ret, err := c.makeCall(ctx, endpoints[endpointCouponCreate], body, &map[string]string{
+		"familyID": fmt.Sprintf("%d", coupon.ProductFamilyID),
+	})
+	if err != nil {
+		return err
+	}
+	apiBody, bodyOK := ret.Body.(map[string]interface{})
+	if !bodyOK {
+		return errors.New("could not understand server response")
+	}
+	return decode(apiBody["coupon"], coupon)
+}
+
+// UpdateCoupon updates an existing coupon.
+func (c *Client) UpdateCoupon(ctx context.Context, coupon *Coupon) error {
+	if err := coupon.validate(); err != nil {
+		return err
+	}
+	body := map[string]Coupon{
+		"coupon": *coupon,
+	}
+	_, err := c.makeCall(ctx, endpoints[endpointCouponUpdate], body, &map[string]string{
+		"familyID": fmt.Sprintf("%d", coupon.ProductFamilyID),
+		"id":       fmt.Sprintf("%d", coupon.ID),
+	})
+	return err
+}
+
+// ArchiveCoupon archives a coupon so it can no longer be redeemed.
+func (c *Client) ArchiveCoupon(ctx context.Context, productFamilyID, couponID int64) error {
+	_, err := c.makeCall(ctx, endpoints[endpointCouponArchive], nil, &map[string]string{
+		"familyID": fmt.Sprintf("%d", productFamilyID),
+		"id":       fmt.Sprintf("%d", couponID),
+	})
+	return err
+}
+
+// FindCouponByCode looks up a coupon by its redemption code within a
+// product family.
+func (c *Client) FindCouponByCode(ctx context.Context, productFamilyID int64, code string) (*Coupon, error) {
+	coupon := &Coupon{}
+	ret, err := c.makeCall(ctx, endpoints[endpointCouponFind], nil, &map[string]string{
+		"familyID": fmt.Sprintf("%d", productFamilyID),
+		"code":     code,
+	})
+	if err != nil {
+		return nil, err
+	}
+	apiBody, bodyOK := ret.Body.(map[string]interface{})
+	if !bodyOK {
+		return nil, errors.New("could not understand server response")
+	}
+	err = decode(apiBody["coupon"], coupon)
+	return coupon, err
+}
+
+// CouponValidation describes whether a coupon code is currently eligible
+// for redemption, and the discount it would apply.
+type CouponValidation struct {
+	Eligible           bool             `json:"eligible"`                      // Whether the code can currently be redeemed
+	Message            string           `json:"message,omitempty"`             // A human-readable reason when not eligible
+	DiscountInCents    int              `json:"discount_in_cents"`             // The computed flat discount, in integer cents, if applicable. Deprecated: prefer Discount, which carries sub-cent precision
+	Discount           Money            `json:"discount,omitempty"`            // The computed flat discount, if applicable. Mirrors DiscountInCents during the deprecation window
+	DiscountPercentage *decimal.Decimal `json:"discount_percentage,omitempty"` // The computed percentage discount, if applicable
+}
+
+// ValidateCoupon checks whether code is currently eligible for redemption
+// within productFamilyID and returns the computed discount.
+func (c *Client) ValidateCoupon(ctx context.Context, productFamilyID int64, code string) (*CouponValidation, error) {
+	validation := &CouponValidation{}
+	ret, err := c.makeCall(ctx, endpoints[endpointCouponValidate], nil, &map[string]string{
+		"familyID": fmt.Sprintf("%d", productFamilyID),
+		"code":     code,
+	})
+	if err != nil {
+		return nil, err
+	}
+	apiBody, bodyOK := ret.Body.(map[string]interface{})
+	if !bodyOK {
+		return nil, errors.New("could not understand server response")
+	}
+	err = decode(apiBody["coupon"], validation)
+	return validation, err
+}
+
+// ListCoupons lists every coupon defined on a product family, honoring
+// opts. Callers expecting more than one page of results should use
+// Client.Coupons instead, which iterates transparently.
+func (c *Client) ListCoupons(ctx context.Context, productFamilyID int64, opts *ListOptions) ([]Coupon, error) {
+	coupons := []Coupon{}
+	params := opts.params()
+	params["familyID"] = fmt.Sprintf("%d", productFamilyID)
+	ret, err := c.makeCall(ctx, endpoints[endpointCouponList], nil, &params)
+	if err != nil {
+		return nil, err
+	}
+
+	temp, ok := ret.Body.([]interface{})
+	if !ok {
+		return nil, errors.New("could not understand server response")
+	}
+	for i := range temp {
+		entry, ok := temp[i].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		coupon := Coupon{}
+		if err := decode(entry["coupon"], &coupon); err == nil {
+			coupons = append(coupons, coupon)
+		}
+	}
+	return coupons, nil
+}
+
+// CouponUsagePreview summarizes the effect a coupon would have if applied
+// to a subscription, without committing the discount.
+type CouponUsagePreview struct {
+	DiscountInCents int   `json:"discount_in_cents"`   // The computed discount for the next billing period, in integer cents. Deprecated: prefer Discount, which carries sub-cent precision
+	Discount        Money `json:"discount,omitempty"`  // The computed discount for the next billing period. Mirrors DiscountInCents during the deprecation window
+	NewTotalInCents int   `json:"new_total_in_cents"`  // The subscription's total after the discount is applied, in integer cents. Deprecated: prefer NewTotal, which carries sub-cent precision
+	NewTotal        Money `json:"new_total,omitempty"` // The subscription's total after the discount is applied. Mirrors NewTotalInCents during the deprecation window
+}
+
+// PreviewCouponUsage shows the effect of applying code to subscriptionID
+// without actually applying it.
+func (c *Client) PreviewCouponUsage(ctx context.Context, subscriptionID int64, code string) (*CouponUsagePreview, error) {
+	preview := &CouponUsagePreview{}
+	ret, err := c.makeCall(ctx, endpoints[endpointCouponPreview], nil, &map[string]string{
+		"subscriptionID": fmt.Sprintf("%d", subscriptionID),
+		"code":           code,
+	})
+	if err != nil {
+		return nil, err
+	}
+	apiBody, bodyOK := ret.Body.(map[string]interface{})
+	if !bodyOK {
+		return nil, errors.New("could not understand server response")
+	}
+	err = decode(apiBody["coupon_usage"], preview)
+	return preview, err
+}
+
+// ApplyCouponToSubscription redeems code against an active subscription.
+func (c *Client) ApplyCouponToSubscription(ctx context.Context, subscriptionID int64, code string) error {
+	body := map[string]map[string]string{
+		"coupon_code": {"code": code},
+	}
+	_, err := c.makeCall(ctx, endpoints[endpointCouponApply], body, &map[string]string{
+		"subscriptionID": fmt.Sprintf("%d", subscriptionID),
+	})
+	return err
+}
+
+// RemoveCouponFromSubscription removes a previously applied coupon from a
+// subscription.
+func (c *Client) RemoveCouponFromSubscription(ctx context.Context, subscriptionID int64, code string) error {
+	_, err := c.makeCall(ctx, endpoints[endpointCouponRemove], nil, &map[string]string{
+		"subscriptionID": fmt.Sprintf("%d", subscriptionID),
+		"code":           code,
+	})
+	return err
+}
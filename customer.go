@@ -0,0 +1,174 @@
+package chargify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// Customer represents a single customer record.
+type Customer struct {
+	ID        int64  `json:"id"`
+	FirstName string `json:"first_name"`          // The customer's first name
+	LastName  string `json:"last_name"`           // The customer's last name
+	Email     string `json:"email"`               // The customer's email address
+	Reference string `json:"reference,omitempty"` // An optional unique identifier used to reference this customer from your own system
+	CreatedAt string `json:"created_at"`          // Timestamp indicating when this customer was created
+	UpdatedAt string `json:"updated_at"`          // Timestamp indicating when this customer was last updated
+}
+
+// CreateCustomer creates a new customer and places the result in the
+// input.
+func (c *Client) CreateCustomer(ctx context.Context, input *Customer) error {
+	if input.FirstName == "" || input.LastName == "" || input.Email == "" {
+		return errors.New("first name, last name, and email are required")
+	}
+	body := map[string]Customer{
+		"customer": *input,
+	}
+
+	ret, err := c.makeCall(ctx, endpoints[endpointCustomerCreate], body, nil)
+	if err != nil {
+		return err
+	}
+	apiBody, bodyOK := ret.Body.(map[string]interface{})
+	if !bodyOK {
+		return errors.New("could not understand server response")
+	}
+	return decode(apiBody["customer"], input)
+}
+
+// GetCustomers lists customers, paginated by page and ordered by
+// direction ("asc" or "desc").
+func (c *Client) GetCustomers(ctx context.Context, page int, direction string) ([]Customer, error) {
+	return c.listCustomers(ctx, &map[string]string{
+		"page":      fmt.Sprintf("%d", page),
+		"direction": direction,
+	})
+}
+
+// SearchForCustomersByEmail returns every customer with the given email
+// address.
+func (c *Client) SearchForCustomersByEmail(ctx context.Context, email string) ([]Customer, error) {
+	return c.listCustomers(ctx, &map[string]string{
+		"q": email,
+	})
+}
+
+func (c *Client) listCustomers(ctx context.Context, params *map[string]string) ([]Customer, error) {
+	customers := []Customer{}
+	ret, err := c.makeCall(ctx, endpoints[endpointCustomerList], nil, params)
+	if err != nil {
+		return nil, err
+	}
+
+	temp, ok := ret.Body.([]interface{})
+	if !ok {
+		return nil, errors.New("could not understand server response")
+	}
+	for i := range temp {
+		entry, ok := temp[i].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		customer := Customer{}
+		if err := decode(entry["customer"], &customer); err == nil {
+			customers = append(customers, customer)
+		}
+	}
+	return customers, nil
+}
+
+// SearchForCustomerByReference finds the customer with the given
+// reference.
+func (c *Client) SearchForCustomerByReference(ctx context.Context, reference string) (*Customer, error) {
+	customer := &Customer{}
+	ret, err := c.makeCall(ctx, endpoints[endpointCustomerSearchByReference], nil, &map[string]string{
+		"reference": reference,
+	})
+	if err != nil {
+		return nil, err
+	}
+	apiBody, bodyOK := ret.Body.(map[string]interface{})
+	if !bodyOK {
+		return nil, errors.New("could not understand server response")
+	}
+	err = decode(apiBody["customer"], customer)
+	return customer, err
+}
+
+// DeleteCustomerByID deletes a customer by id.
+func (c *Client) DeleteCustomerByID(ctx context.Context, customerID int64) error {
+	_, err := c.makeCall(ctx, endpoints[endpointCustomerDelete], nil, &map[string]string{
+		"id": fmt.Sprintf("%d", customerID),
+	})
+	return err
+}
+
+// CreateCustomer creates a new customer using DefaultClient.
+//
+// Deprecated: construct a *Client with NewClient and call
+// Client.CreateCustomer instead. This wrapper will be removed in a future
+// release.
+func CreateCustomer(input *Customer) (*Customer, error) {
+	c, err := defaultClient()
+	if err != nil {
+		return nil, err
+	}
+	err = c.CreateCustomer(context.Background(), input)
+	return input, err
+}
+
+// GetCustomers lists customers using DefaultClient.
+//
+// Deprecated: construct a *Client with NewClient and call
+// Client.GetCustomers instead. This wrapper will be removed in a future
+// release.
+func GetCustomers(page int, direction string) ([]Customer, error) {
+	c, err := defaultClient()
+	if err != nil {
+		return nil, err
+	}
+	return c.GetCustomers(context.Background(), page, direction)
+}
+
+// SearchForCustomersByEmail returns every customer with the given email
+// address using DefaultClient.
+//
+// Deprecated: construct a *Client with NewClient and call
+// Client.SearchForCustomersByEmail instead. This wrapper will be removed
+// in a future release.
+func SearchForCustomersByEmail(email string) ([]Customer, error) {
+	c, err := defaultClient()
+	if err != nil {
+		return nil, err
+	}
+	return c.SearchForCustomersByEmail(context.Background(), email)
+}
+
+// SearchForCustomerByReference finds the customer with the given
+// reference using DefaultClient.
+//
+// Deprecated: construct a *Client with NewClient and call
+// Client.SearchForCustomerByReference instead. This wrapper will be
+// removed in a future release.
+func SearchForCustomerByReference(reference string) (*Customer, error) {
+	c, err := defaultClient()
+	if err != nil {
+		return nil, err
+	}
+	return c.SearchForCustomerByReference(context.Background(), reference)
+}
+
+// DeleteCustomerByID deletes a customer by id using DefaultClient.
+//
+// Deprecated: construct a *Client with NewClient and call
+// Client.DeleteCustomerByID instead. This wrapper will be removed in a
+// future release.
+func DeleteCustomerByID(customerID int64) error {
+	c, err := defaultClient()
+	if err != nil {
+		return err
+	}
+	return c.DeleteCustomerByID(context.Background(), customerID)
+}
@@ -3,13 +3,39 @@ package chargify
 import (
 	"fmt"
 	"math/rand"
+	"os"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
+// TestMain configures DefaultClient from CHARGIFY_SUBDOMAIN and
+// CHARGIFY_API_KEY when they're set, so TestCustomerCreation can
+// exercise the deprecated package-level wrapper functions against the
+// real Chargify API. It always runs the rest of the suite - the package's
+// other tests have no network dependency and must not be skipped along
+// with this one.
+func TestMain(m *testing.M) {
+	subdomain := os.Getenv("CHARGIFY_SUBDOMAIN")
+	apiKey := os.Getenv("CHARGIFY_API_KEY")
+	if subdomain != "" && apiKey != "" {
+		client, err := NewClient(Config{Subdomain: subdomain, APIKey: apiKey})
+		if err != nil {
+			fmt.Println("could not configure default client:", err)
+			os.Exit(1)
+		}
+		SetDefaultClient(client)
+	}
+
+	os.Exit(m.Run())
+}
+
 func TestCustomerCreation(t *testing.T) {
+	if DefaultClient == nil {
+		t.Skip("CHARGIFY_SUBDOMAIN and CHARGIFY_API_KEY are not set")
+	}
+
 	customID := rand.Int63n(999999999)
 	input := Customer{
 		FirstName: fmt.Sprintf("First-%d", customID),
@@ -0,0 +1,98 @@
+package chargify
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/shopspring/decimal"
+)
+
+// moneyType is compared against by moneyDecodeHook to recognize Money
+// destination fields.
+var moneyType = reflect.TypeOf(Money{})
+
+// moneyDecodeHook lets decode populate Money fields directly from the bare
+// scalar values Chargify's wire format uses for money - an integer number
+// of cents, or a decimal string for newer endpoints - the same formats
+// Money.UnmarshalJSON accepts. Without this hook, mapstructure's default
+// reflection-based decoding can neither convert a scalar into a struct
+// nor reach Money's unexported amount field.
+func moneyDecodeHook(_ reflect.Type, to reflect.Type, data interface{}) (interface{}, error) {
+	if to != moneyType {
+		return data, nil
+	}
+
+	switch v := data.(type) {
+	case string:
+		amount, err := decimal.NewFromString(v)
+		if err != nil {
+			return nil, fmt.Errorf("chargify: could not parse money amount %q", v)
+		}
+		return NewMoney(amount, ""), nil
+	case float64:
+		return NewMoneyFromCents(int(v), ""), nil
+	case int:
+		return NewMoneyFromCents(v, ""), nil
+	case map[string]interface{}:
+		amountStr, _ := v["amount"].(string)
+		amount, err := decimal.NewFromString(amountStr)
+		if err != nil {
+			return nil, fmt.Errorf("chargify: could not parse money amount %q", amountStr)
+		}
+		currency, _ := v["currency"].(string)
+		return NewMoney(amount, currency), nil
+	default:
+		return data, nil
+	}
+}
+
+// matchFieldName reports whether mapKey and fieldName refer to the same
+// field, ignoring case and underscores. mapstructure calls this with
+// fieldName already resolved to the field's `json` tag (see decode's
+// TagName), so this only needs to handle the handful of fields with no
+// json tag, comparing the raw Go field name instead.
+func matchFieldName(mapKey, fieldName string) bool {
+	normalize := func(s string) string {
+		return strings.ToLower(strings.ReplaceAll(s, "_", ""))
+	}
+	return normalize(mapKey) == normalize(fieldName)
+}
+
+// decode populates target (a pointer) from raw - typically the
+// map[string]interface{} or []interface{} produced by decoding the API's
+// JSON response - the same way mapstructure.Decode does, but resolving
+// fields by their `json` tag (every model in this package is tagged for
+// encoding/json, not mapstructure) with support for Money fields via
+// moneyDecodeHook and untagged fields via matchFieldName.
+func decode(raw interface{}, target interface{}) error {
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		TagName:    "json",
+		DecodeHook: moneyDecodeHook,
+		MatchName:  matchFieldName,
+		Result:     target,
+	})
+	if err != nil {
+		return err
+	}
+	return decoder.Decode(raw)
+}
+
+// decodeForm populates target (a pointer) from raw, the same way decode
+// does, but with WeaklyTypedInput enabled so that the all-string values
+// produced by parsing a form-encoded webhook payload convert into the
+// target's typed (e.g. int64) fields.
+func decodeForm(raw interface{}, target interface{}) error {
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		TagName:          "json",
+		DecodeHook:       moneyDecodeHook,
+		MatchName:        matchFieldName,
+		WeaklyTypedInput: true,
+		Result:           target,
+	})
+	if err != nil {
+		return err
+	}
+	return decoder.Decode(raw)
+}
@@ -0,0 +1,48 @@
+package chargify
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeSnakeCaseFields(t *testing.T) {
+	raw := map[string]interface{}{
+		"price_in_cents": 1999,
+		"interval_unit":  "month",
+		"interval":       1,
+		"name":           "Gold Plan",
+		"handle":         "gold-plan",
+	}
+
+	var product Product
+	require.NoError(t, decode(raw, &product))
+	assert.Equal(t, 1999, product.PriceInCents)
+	assert.Equal(t, ProductInterval("month"), product.IntervalUnit)
+	assert.Equal(t, 1, product.IntervalValue)
+	assert.Equal(t, "Gold Plan", product.Name)
+	assert.Equal(t, "gold-plan", product.Handle)
+}
+
+// TestDecodeAbbreviatedJSONTags covers fields whose json tag abbreviates
+// the Go field name rather than just snake_casing it, so the response
+// key can only be matched by consulting the json tag, not the field
+// name.
+func TestDecodeAbbreviatedJSONTags(t *testing.T) {
+	raw := map[string]interface{}{
+		"interval":            1,
+		"expiration_interval": 12,
+		"archived_at":         "2024-01-01T00:00:00Z",
+	}
+
+	var product Product
+	require.NoError(t, decode(raw, &product))
+	assert.Equal(t, 1, product.IntervalValue)
+	assert.Equal(t, 12, product.ExpirationIntervalValue)
+	assert.Equal(t, "2024-01-01T00:00:00Z", product.Archived)
+
+	var component Component
+	require.NoError(t, decode(map[string]interface{}{"taxable": true}, &component))
+	assert.True(t, component.TaxableFlag)
+}
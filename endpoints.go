@@ -0,0 +1,139 @@
+package chargify
+
+import "net/http"
+
+// endpointKey identifies one entry in the endpoints registry below.
+type endpointKey string
+
+// endpointSpec pairs an HTTP method with a path template. Path segments
+// wrapped in braces (e.g. "{productID}") are substituted from the params
+// map passed to makeCall; any params entries that are not consumed as
+// path placeholders are sent as URL query parameters instead.
+type endpointSpec struct {
+	HTTPMethod   string
+	PathTemplate string
+	Raw          bool // true if the response body is not JSON (e.g. a PDF) and should be returned unparsed
+}
+
+// Method returns the HTTP method this endpoint is called with.
+func (e endpointSpec) Method() string {
+	return e.HTTPMethod
+}
+
+const (
+	endpointProductFamilyCreate endpointKey = "product_family_create"
+	endpointProductFamilyGet    endpointKey = "product_family_get"
+	endpointProductCreate       endpointKey = "product_create"
+	endpointProductGetByID      endpointKey = "product_get_by_id"
+	endpointProductGetForFamily endpointKey = "product_get_for_family"
+	endpointProductGetByHandle  endpointKey = "product_get_by_handle"
+	endpointProductUpdate       endpointKey = "product_update"
+	endpointProductArchive      endpointKey = "product_archive"
+
+	endpointPricePointCreate     endpointKey = "price_point_create"
+	endpointPricePointList       endpointKey = "price_point_list"
+	endpointPricePointUpdate     endpointKey = "price_point_update"
+	endpointPricePointArchive    endpointKey = "price_point_archive"
+	endpointPricePointPromote    endpointKey = "price_point_promote"
+	endpointPricePointBulkCreate endpointKey = "price_point_bulk_create"
+
+	endpointCouponCreate   endpointKey = "coupon_create"
+	endpointCouponUpdate   endpointKey = "coupon_update"
+	endpointCouponArchive  endpointKey = "coupon_archive"
+	endpointCouponFind     endpointKey = "coupon_find"
+	endpointCouponValidate endpointKey = "coupon_validate"
+	endpointCouponList     endpointKey = "coupon_list"
+	endpointCouponPreview  endpointKey = "coupon_preview"
+	endpointCouponApply    endpointKey = "coupon_apply"
+	endpointCouponRemove   endpointKey = "coupon_remove"
+
+	endpointComponentCreate            endpointKey = "component_create"
+	endpointComponentList              endpointKey = "component_list"
+	endpointComponentAllocate          endpointKey = "component_allocate"
+	endpointComponentAllocationPreview endpointKey = "component_allocation_preview"
+	endpointComponentRecordUsage       endpointKey = "component_record_usage"
+	endpointComponentBulkRecordUsage   endpointKey = "component_bulk_record_usage"
+	endpointEventRecord                endpointKey = "event_record"
+
+	endpointInvoiceList          endpointKey = "invoice_list"
+	endpointInvoiceGet           endpointKey = "invoice_get"
+	endpointInvoiceVoid          endpointKey = "invoice_void"
+	endpointInvoiceRefund        endpointKey = "invoice_refund"
+	endpointInvoiceRecordPayment endpointKey = "invoice_record_payment"
+	endpointInvoiceIssue         endpointKey = "invoice_issue"
+	endpointInvoiceSend          endpointKey = "invoice_send"
+	endpointInvoicePDF           endpointKey = "invoice_pdf"
+	endpointProformaPreview      endpointKey = "proforma_preview"
+
+	endpointWebhookEndpointCreate endpointKey = "webhook_endpoint_create"
+	endpointWebhookEndpointList   endpointKey = "webhook_endpoint_list"
+	endpointWebhookReplay         endpointKey = "webhook_replay"
+
+	endpointCustomerCreate            endpointKey = "customer_create"
+	endpointCustomerList              endpointKey = "customer_list"
+	endpointCustomerSearchByReference endpointKey = "customer_search_by_reference"
+	endpointCustomerDelete            endpointKey = "customer_delete"
+
+	endpointSubscriptionList endpointKey = "subscription_list"
+)
+
+// endpoints is the registry of every API operation this package knows how
+// to call. Callers look up the matching endpointSpec here and pass it to
+// makeCall; doAPICall then substitutes path placeholders from the
+// caller-supplied params and issues the request.
+var endpoints = map[endpointKey]endpointSpec{
+	endpointProductFamilyCreate: {http.MethodPost, "/product_families.json", false},
+	endpointProductFamilyGet:    {http.MethodGet, "/product_families/{id}.json", false},
+	endpointProductCreate:       {http.MethodPost, "/product_families/{familyID}/products.json", false},
+	endpointProductGetByID:      {http.MethodGet, "/products/{id}.json", false},
+	endpointProductGetForFamily: {http.MethodGet, "/product_families/{familyID}/products.json", false},
+	endpointProductGetByHandle:  {http.MethodGet, "/products/handle/{handle}.json", false},
+	endpointProductUpdate:       {http.MethodPut, "/products/{productID}.json", false},
+	endpointProductArchive:      {http.MethodDelete, "/products/{id}.json", false},
+
+	endpointPricePointCreate:     {http.MethodPost, "/products/{productID}/price_points.json", false},
+	endpointPricePointList:       {http.MethodGet, "/products/{productID}/price_points.json", false},
+	endpointPricePointUpdate:     {http.MethodPut, "/products/{productID}/price_points/{pricePointID}.json", false},
+	endpointPricePointArchive:    {http.MethodDelete, "/products/{productID}/price_points/{pricePointID}.json", false},
+	endpointPricePointPromote:    {http.MethodPut, "/products/{productID}/price_points/{pricePointID}/default.json", false},
+	endpointPricePointBulkCreate: {http.MethodPost, "/products/{productID}/price_points/bulk.json", false},
+
+	endpointCouponCreate:   {http.MethodPost, "/product_families/{familyID}/coupons.json", false},
+	endpointCouponUpdate:   {http.MethodPut, "/product_families/{familyID}/coupons/{id}.json", false},
+	endpointCouponArchive:  {http.MethodDelete, "/product_families/{familyID}/coupons/{id}.json", false},
+	endpointCouponFind:     {http.MethodGet, "/product_families/{familyID}/coupons/find.json", false},
+	endpointCouponValidate: {http.MethodGet, "/product_families/{familyID}/coupons/validate.json", false},
+	endpointCouponList:     {http.MethodGet, "/product_families/{familyID}/coupons.json", false},
+	endpointCouponPreview:  {http.MethodGet, "/subscriptions/{subscriptionID}/coupons/preview.json", false},
+	endpointCouponApply:    {http.MethodPost, "/subscriptions/{subscriptionID}/add_coupon.json", false},
+	endpointCouponRemove:   {http.MethodDelete, "/subscriptions/{subscriptionID}/remove_coupon.json", false},
+
+	endpointComponentCreate:            {http.MethodPost, "/product_families/{familyID}/components.json", false},
+	endpointComponentList:              {http.MethodGet, "/product_families/{familyID}/components.json", false},
+	endpointComponentAllocate:          {http.MethodPost, "/subscriptions/{subscriptionID}/components/{componentID}/allocations.json", false},
+	endpointComponentAllocationPreview: {http.MethodGet, "/subscriptions/{subscriptionID}/components/{componentID}/allocations/preview.json", false},
+	endpointComponentRecordUsage:       {http.MethodPost, "/subscriptions/{subscriptionID}/components/{componentID}/usages.json", false},
+	endpointComponentBulkRecordUsage:   {http.MethodPost, "/subscriptions/{subscriptionID}/components/{componentID}/usages/bulk.json", false},
+	endpointEventRecord:                {http.MethodPost, "/events_based_billing/streams/{streamHandle}/events.json", false},
+
+	endpointInvoiceList:          {http.MethodGet, "/invoices.json", false},
+	endpointInvoiceGet:           {http.MethodGet, "/invoices/{uid}.json", false},
+	endpointInvoiceVoid:          {http.MethodPut, "/invoices/{uid}/void.json", false},
+	endpointInvoiceRefund:        {http.MethodPost, "/invoices/{uid}/refunds.json", false},
+	endpointInvoiceRecordPayment: {http.MethodPost, "/invoices/{uid}/payments.json", false},
+	endpointInvoiceIssue:         {http.MethodPut, "/invoices/{uid}/issue_invoice.json", false},
+	endpointInvoiceSend:          {http.MethodPost, "/invoices/{uid}/deliveries.json", false},
+	endpointInvoicePDF:           {http.MethodGet, "/invoices/{uid}.pdf", true},
+	endpointProformaPreview:      {http.MethodPost, "/subscriptions/preview.json", false},
+
+	endpointWebhookEndpointCreate: {http.MethodPost, "/webhooks/endpoints.json", false},
+	endpointWebhookEndpointList:   {http.MethodGet, "/webhooks/endpoints.json", false},
+	endpointWebhookReplay:         {http.MethodPost, "/webhooks/{id}/replay.json", false},
+
+	endpointCustomerCreate:            {http.MethodPost, "/customers.json", false},
+	endpointCustomerList:              {http.MethodGet, "/customers.json", false},
+	endpointCustomerSearchByReference: {http.MethodGet, "/customers/lookup.json", false},
+	endpointCustomerDelete:            {http.MethodDelete, "/customers/{id}.json", false},
+
+	endpointSubscriptionList: {http.MethodGet, "/subscriptions.json", false},
+}
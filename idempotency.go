@@ -0,0 +1,135 @@
+package chargify
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math"
+	mathrand "math/rand"
+	"net/http"
+	"time"
+)
+
+// idempotencyKeyHeader is sent on every mutating request so that retries
+// (by this client or by an at-least-once job queue replaying the call)
+// land on the same underlying operation instead of creating duplicate
+// customers, subscriptions, or charges.
+const idempotencyKeyHeader = "Idempotency-Key"
+
+// RequestOption customizes a single API call. It is accepted as a
+// trailing variadic argument on makeCall so new options can be added
+// without breaking existing call sites.
+type RequestOption func(*requestOptions)
+
+type requestOptions struct {
+	idempotencyKey string
+}
+
+// WithIdempotencyKey attaches key as the request's Idempotency-Key header.
+// If not supplied on a mutating request, the Client generates a random
+// UUIDv4 key automatically and logs it. RequestOptions are accepted only
+// by the unexported makeCall; none of the exported Client methods takes
+// them, so calls made through those methods always carry a generated key.
+func WithIdempotencyKey(key string) RequestOption {
+	return func(o *requestOptions) {
+		o.idempotencyKey = key
+	}
+}
+
+func resolveRequestOptions(opts []RequestOption) requestOptions {
+	resolved := requestOptions{}
+	for _, opt := range opts {
+		opt(&resolved)
+	}
+	return resolved
+}
+
+// IdempotencyKeyError wraps the error from a mutating request that
+// ultimately failed (after its last retry, on a non-retryable error, or on
+// context cancellation), carrying the Idempotency-Key that was sent. Without it, a caller driven by an at-least-once job queue - the
+// whole reason WithIdempotencyKey exists - would have no way to recover
+// the key for the one call that matters most: the one that ultimately
+// failed.
+type IdempotencyKeyError struct {
+	Err            error
+	IdempotencyKey string
+}
+
+func (e *IdempotencyKeyError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *IdempotencyKeyError) Unwrap() error {
+	return e.Err
+}
+
+// wrapIdempotencyKeyError wraps err in an IdempotencyKeyError when it
+// came from a failed mutating call that carried a key.
+func wrapIdempotencyKeyError(mutating bool, key string, err error) error {
+	if !mutating || key == "" || err == nil {
+		return err
+	}
+	return &IdempotencyKeyError{Err: err, IdempotencyKey: key}
+}
+
+// newIdempotencyKey generates a random UUIDv4, used when a mutating
+// request is not given an explicit idempotency key.
+func newIdempotencyKey() (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", err
+	}
+	b[6] = (b[6] & 0x0f) | 0x40 // version 4
+	b[8] = (b[8] & 0x3f) | 0x80 // RFC 4122 variant
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16]), nil
+}
+
+// isMutatingMethod reports whether method should carry an idempotency key
+// and be subject to retry-with-backoff.
+func isMutatingMethod(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodDelete, http.MethodPatch:
+		return true
+	default:
+		return false
+	}
+}
+
+// isRetryableStatus reports whether a response status warrants a retry
+// under the Client's RetryPolicy.
+func isRetryableStatus(status int) bool {
+	return status == http.StatusTooManyRequests || status >= 500
+}
+
+// backoffDelay computes the exponential-backoff-with-jitter delay before
+// attempt (1-indexed), honoring retryAfter when the server supplied one,
+// and capping at policy.MaxDelay.
+func backoffDelay(policy RetryPolicy, attempt int, retryAfter time.Duration) time.Duration {
+	if retryAfter > 0 {
+		if retryAfter > policy.MaxDelay {
+			return policy.MaxDelay
+		}
+		return retryAfter
+	}
+
+	backoff := time.Duration(float64(policy.BaseDelay) * math.Pow(2, float64(attempt-1)))
+	if backoff > policy.MaxDelay {
+		backoff = policy.MaxDelay
+	}
+	// Full jitter: a random delay between 0 and the computed backoff,
+	// which spreads out retries from many concurrent callers.
+	jittered := time.Duration(mathrand.Int63n(int64(backoff) + 1))
+	return jittered
+}
+
+// parseRetryAfter parses a Retry-After header, which Chargify sends as a
+// number of seconds.
+func parseRetryAfter(header string) time.Duration {
+	if header == "" {
+		return 0
+	}
+	var seconds int
+	if _, err := fmt.Sscanf(header, "%d", &seconds); err != nil || seconds <= 0 {
+		return 0
+	}
+	return time.Duration(seconds) * time.Second
+}
@@ -0,0 +1,58 @@
+package chargify
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRetryAfter(t *testing.T) {
+	cases := []struct {
+		name   string
+		header string
+		want   time.Duration
+	}{
+		{"empty", "", 0},
+		{"seconds", "30", 30 * time.Second},
+		{"zero", "0", 0},
+		{"negative", "-5", 0},
+		{"not a number", "soon", 0},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, parseRetryAfter(tc.header))
+		})
+	}
+}
+
+func TestBackoffDelayHonorsRetryAfterCappedAtMaxDelay(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 3, BaseDelay: 100 * time.Millisecond, MaxDelay: 2 * time.Second}
+
+	assert.Equal(t, time.Second, backoffDelay(policy, 1, time.Second))
+	assert.Equal(t, policy.MaxDelay, backoffDelay(policy, 1, 10*time.Second))
+}
+
+func TestBackoffDelayExponentialWithJitter(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 5, BaseDelay: 100 * time.Millisecond, MaxDelay: 2 * time.Second}
+
+	// attempt 3 -> uncapped backoff of 100ms * 2^2 = 400ms; jitter picks
+	// a random delay in [0, backoff], so every sample must fall in range.
+	for i := 0; i < 50; i++ {
+		got := backoffDelay(policy, 3, 0)
+		assert.GreaterOrEqual(t, got, time.Duration(0))
+		assert.LessOrEqual(t, got, 400*time.Millisecond)
+	}
+}
+
+func TestBackoffDelayCapsAtMaxDelay(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 10, BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+
+	// attempt 10 -> uncapped backoff would be enormous; jitter must stay
+	// within [0, MaxDelay].
+	for i := 0; i < 50; i++ {
+		got := backoffDelay(policy, 10, 0)
+		assert.LessOrEqual(t, got, policy.MaxDelay)
+	}
+}
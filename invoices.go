@@ -0,0 +1,269 @@
+package chargify
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+// InvoiceStatus is the lifecycle state of an Invoice.
+type InvoiceStatus string
+
+var (
+	// InvoiceStatusDraft has not yet been issued to the customer.
+	InvoiceStatusDraft InvoiceStatus = "draft"
+	// InvoiceStatusOpen has been issued and is awaiting payment.
+	InvoiceStatusOpen InvoiceStatus = "open"
+	// InvoiceStatusPaid has been paid in full.
+	InvoiceStatusPaid InvoiceStatus = "paid"
+	// InvoiceStatusPending is awaiting a payment attempt.
+	InvoiceStatusPending InvoiceStatus = "pending"
+	// InvoiceStatusVoided has been voided and is no longer collectible.
+	InvoiceStatusVoided InvoiceStatus = "voided"
+	// InvoiceStatusCanceled has been canceled and is no longer collectible.
+	InvoiceStatusCanceled InvoiceStatus = "canceled"
+)
+
+// Address is a billing or shipping address attached to an Invoice.
+type Address struct {
+	Line1   string `json:"line1,omitempty"`
+	Line2   string `json:"line2,omitempty"`
+	City    string `json:"city,omitempty"`
+	State   string `json:"state,omitempty"`
+	Zip     string `json:"zip,omitempty"`
+	Country string `json:"country,omitempty"`
+}
+
+// InvoiceLineItem is a single billed item on an Invoice.
+type InvoiceLineItem struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Quantity    int    `json:"quantity"`
+	UnitPrice   Money  `json:"unit_price"`
+	Subtotal    Money  `json:"subtotal"`
+}
+
+// Invoice represents a single billing document issued to a customer,
+// mirroring Chargify's invoice controller.
+type Invoice struct {
+	UID             string            `json:"uid"`
+	SiteID          int64             `json:"site_id"`
+	CustomerID      int64             `json:"customer_id"`
+	SubscriptionID  int64             `json:"subscription_id"`
+	Number          string            `json:"number"`
+	SequenceNumber  int               `json:"sequence_number"`
+	IssueDate       string            `json:"issue_date,omitempty"`
+	DueDate         string            `json:"due_date,omitempty"`
+	PaidDate        string            `json:"paid_date,omitempty"`
+	Status          InvoiceStatus     `json:"status"`
+	Currency        string            `json:"currency"`
+	Memo            string            `json:"memo,omitempty"`
+	BillingAddress  *Address          `json:"billing_address,omitempty"`
+	ShippingAddress *Address          `json:"shipping_address,omitempty"`
+	LineItems       []InvoiceLineItem `json:"line_items,omitempty"`
+	Discounts       []InvoiceLineItem `json:"discounts,omitempty"`
+	Taxes           []InvoiceLineItem `json:"taxes,omitempty"`
+	Credits         []InvoiceLineItem `json:"credits,omitempty"`
+	Refunds         []InvoiceLineItem `json:"refunds,omitempty"`
+	Payments        []Payment         `json:"payments,omitempty"`
+	TotalAmount     Money             `json:"total_amount"`
+	PaidAmount      Money             `json:"paid_amount"`
+	DueAmount       Money             `json:"due_amount"`
+}
+
+// Payment is a single payment (or recorded external payment) applied to
+// an Invoice.
+type Payment struct {
+	Amount        Money  `json:"amount"`
+	Memo          string `json:"memo,omitempty"`
+	PaymentMethod string `json:"payment_method,omitempty"` // e.g. "check", "wire", "cash" for external payments
+	AppliedAt     string `json:"applied_at,omitempty"`
+}
+
+// RefundRequest describes a refund to apply to a paid Invoice.
+type RefundRequest struct {
+	Amount Money  `json:"amount"`
+	Memo   string `json:"memo,omitempty"`
+}
+
+// ListInvoices lists invoices across the site, honoring opts.
+func (c *Client) ListInvoices(ctx context.Context, opts *ListOptions) ([]Invoice, error) {
+	invoices := []Invoice{}
+	params := opts.params()
+	ret, err := c.makeCall(ctx, endpoints[endpointInvoiceList], nil, &params)
+	if err != nil {
+		return nil, err
+	}
+
+	temp, ok := ret.Body.([]interface{})
+	if !ok {
+		return nil, errors.New("could not understand server response")
+	}
+	for i := range temp {
+		entry, ok := temp[i].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		invoice := Invoice{}
+		if err := decode(entry["invoice"], &invoice); err == nil {
+			invoices = append(invoices, invoice)
+		}
+	}
+	return invoices, nil
+}
+
+// GetInvoice fetches a single invoice by its uid.
+func (c *Client) GetInvoice(ctx context.Context, uid string) (*Invoice, error) {
+	invoice := &Invoice{}
+	ret, err := c.makeCall(ctx, endpoints[endpointInvoiceGet], nil, &map[string]string{
+		"uid": uid,
+	})
+	if err != nil {
+		return nil, err
+	}
+	apiBody, bodyOK := ret.Body.(map[string]interface{})
+	if !bodyOK {
+		return nil, errors.New("could not understand server response")
+	}
+	err = decode(apiBody["invoice"], invoice)
+	return invoice, err
+}
+
+// VoidInvoice voids an invoice, making it no longer collectible.
+func (c *Client) VoidInvoice(ctx context.Context, uid string) error {
+	_, err := c.makeCall(ctx, endpoints[endpointInvoiceVoid], nil, &map[string]string{
+		"uid": uid,
+	})
+	return err
+}
+
+// RefundInvoice refunds all or part of a paid invoice.
+func (c *Client) RefundInvoice(ctx context.Context, uid string, req RefundRequest) error {
+	body := map[string]RefundRequest{
+		"refund": req,
+	}
+	_, err := c.makeCall(ctx, endpoints[endpointInvoiceRefund], body, &map[string]string{
+		"uid": uid,
+	})
+	return err
+}
+
+// RecordExternalPayment records a payment collected outside of Chargify
+// (e.g. a wire transfer) against an invoice.
+func (c *Client) RecordExternalPayment(ctx context.Context, uid string, payment Payment) error {
+	body := map[string]Payment{
+		"payment": payment,
+	}
+	_, err := c.makeCall(ctx, endpoints[endpointInvoiceRecordPayment], body, &map[string]string{
+		"uid": uid,
+	})
+	return err
+}
+
+// IssueInvoice transitions a draft invoice to open, making it collectible.
+func (c *Client) IssueInvoice(ctx context.Context, uid string) error {
+	_, err := c.makeCall(ctx, endpoints[endpointInvoiceIssue], nil, &map[string]string{
+		"uid": uid,
+	})
+	return err
+}
+
+// SendInvoice emails an invoice to the given address.
+func (c *Client) SendInvoice(ctx context.Context, uid, email string) error {
+	body := map[string]map[string]string{
+		"delivery": {"email": email},
+	}
+	_, err := c.makeCall(ctx, endpoints[endpointInvoiceSend], body, &map[string]string{
+		"uid": uid,
+	})
+	return err
+}
+
+// DownloadInvoicePDF returns a reader over the invoice's rendered PDF.
+// The caller is responsible for closing it.
+func (c *Client) DownloadInvoicePDF(ctx context.Context, uid string) (io.ReadCloser, error) {
+	ret, err := c.makeCall(ctx, endpoints[endpointInvoicePDF], nil, &map[string]string{
+		"uid": uid,
+	})
+	if err != nil {
+		return nil, err
+	}
+	rc, ok := ret.Body.(io.ReadCloser)
+	if !ok {
+		return nil, errors.New("could not understand server response")
+	}
+	return rc, nil
+}
+
+// ProformaInvoice previews the invoice that would be generated for a new
+// or changed subscription, without creating any billing records.
+type ProformaInvoice struct {
+	Currency    string            `json:"currency"`
+	LineItems   []InvoiceLineItem `json:"line_items,omitempty"`
+	Taxes       []InvoiceLineItem `json:"taxes,omitempty"`
+	TotalAmount Money             `json:"total_amount"`
+	DueAmount   Money             `json:"due_amount"`
+}
+
+// SubscriptionPreviewRequest describes the subscription that a
+// ProformaInvoice should be quoted against.
+type SubscriptionPreviewRequest struct {
+	ProductID    int64            `json:"product_id"`
+	PricePointID int64            `json:"price_point_id,omitempty"`
+	CouponCode   string           `json:"coupon_code,omitempty"`
+	Components   []ComponentPrice `json:"components,omitempty"`
+}
+
+// PreviewSubscriptionProforma quotes a new subscription described by
+// subReq before it is committed, mirroring the advance-invoice controller.
+func (c *Client) PreviewSubscriptionProforma(ctx context.Context, subReq SubscriptionPreviewRequest) (*ProformaInvoice, error) {
+	body := map[string]SubscriptionPreviewRequest{
+		"subscription": subReq,
+	}
+	ret, err := c.makeCall(ctx, endpoints[endpointProformaPreview], body, nil)
+	if err != nil {
+		return nil, err
+	}
+	apiBody, bodyOK := ret.Body.(map[string]interface{})
+	if !bodyOK {
+		return nil, errors.New("could not understand server response")
+	}
+	proforma := &ProformaInvoice{}
+	err = decode(apiBody["proforma_invoice"], proforma)
+	return proforma, err
+}
+
+// InvoiceIter walks every page of a ListInvoices-style result set,
+// honoring a server-provided Link header when present and otherwise
+// incrementing ListOptions.Page.
+type InvoiceIter struct {
+	*pageIter[Invoice]
+}
+
+// Invoices returns an InvoiceIter honoring opts.
+func (c *Client) Invoices(opts *ListOptions) *InvoiceIter {
+	return &InvoiceIter{newPageIter(opts, func(ctx context.Context, opts *ListOptions) ([]Invoice, string, error) {
+		params := opts.params()
+		ret, err := c.makeCall(ctx, endpoints[endpointInvoiceList], nil, &params)
+		if err != nil {
+			return nil, "", err
+		}
+
+		temp, ok := ret.Body.([]interface{})
+		if !ok {
+			return nil, "", errors.New("could not understand server response")
+		}
+		page := []Invoice{}
+		for i := range temp {
+			entry, ok := temp[i].(map[string]interface{})
+			if !ok {
+				continue
+			}
+			invoice := Invoice{}
+			if err := decode(entry["invoice"], &invoice); err == nil {
+				page = append(page, invoice)
+			}
+		}
+		return page, ret.LinkNext, nil
+	})}
+}
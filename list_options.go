@@ -0,0 +1,362 @@
+package chargify
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SortDirection controls the order in which a list endpoint returns
+// results.
+type SortDirection string
+
+var (
+	// SortAscending orders results oldest/lowest first.
+	SortAscending SortDirection = "asc"
+	// SortDescending orders results newest/highest first.
+	SortDescending SortDirection = "desc"
+)
+
+// ListOptions carries paging, sorting, filtering and include settings for
+// a list request. It is accepted by ListProductPricePoints, ListCoupons,
+// ListComponents and ListInvoices, and by the Customers, Products,
+// Coupons, Components, Subscriptions and Invoices iterators. GetCustomers
+// (which still takes a bare page and direction), SearchForCustomersByEmail,
+// GetProductsInFamily and WebhooksClient.ListEndpoints do not take it. A
+// nil *ListOptions requests the server's defaults.
+type ListOptions struct {
+	Page      int           // Which page to fetch, 1-indexed. Zero uses the server default
+	PerPage   int           // Results per page. Zero uses the server default
+	Direction SortDirection // Sort direction. Empty uses the server default
+	SortField string        // Field to sort by. Empty uses the server default
+
+	Filter map[string]string // Additional field=value filters, passed through as query parameters
+
+	DateRangeStart time.Time // Restricts results to those created/updated on or after this time, if non-zero
+	DateRangeEnd   time.Time // Restricts results to those created/updated on or before this time, if non-zero
+
+	Include []string // Related resources to eagerly include in the response, e.g. "price_points"
+}
+
+// params flattens opts into the query parameter map makeCall expects. A
+// nil opts returns an empty map.
+func (opts *ListOptions) params() map[string]string {
+	params := map[string]string{}
+	if opts == nil {
+		return params
+	}
+
+	if opts.Page > 0 {
+		params["page"] = fmt.Sprintf("%d", opts.Page)
+	}
+	if opts.PerPage > 0 {
+		params["per_page"] = fmt.Sprintf("%d", opts.PerPage)
+	}
+	if opts.Direction != "" {
+		params["direction"] = string(opts.Direction)
+	}
+	if opts.SortField != "" {
+		params["sort"] = opts.SortField
+	}
+	if !opts.DateRangeStart.IsZero() {
+		params["date_range_start"] = opts.DateRangeStart.Format(time.RFC3339)
+	}
+	if !opts.DateRangeEnd.IsZero() {
+		params["date_range_end"] = opts.DateRangeEnd.Format(time.RFC3339)
+	}
+	if len(opts.Include) > 0 {
+		params["include"] = strings.Join(opts.Include, ",")
+	}
+	for k, v := range opts.Filter {
+		params[k] = v
+	}
+	return params
+}
+
+// nextPage returns a copy of opts advanced to the next page. A nil opts
+// starts iteration at page 2 (page 1 having already been fetched as the
+// initial request).
+func (opts *ListOptions) nextPage() *ListOptions {
+	next := ListOptions{}
+	if opts != nil {
+		next = *opts
+	}
+	if next.Page == 0 {
+		next.Page = 1
+	}
+	next.Page++
+	return &next
+}
+
+// nextFromLink returns a copy of opts set to fetch the page identified by
+// linkNext, a "next" relation URL as returned in an API response's Link
+// header. It falls back to nextPage if linkNext carries no recognizable
+// page number.
+func (opts *ListOptions) nextFromLink(linkNext string) *ListOptions {
+	parsed, err := url.Parse(linkNext)
+	if err != nil {
+		return opts.nextPage()
+	}
+	page, err := strconv.Atoi(parsed.Query().Get("page"))
+	if err != nil {
+		return opts.nextPage()
+	}
+
+	next := ListOptions{}
+	if opts != nil {
+		next = *opts
+	}
+	next.Page = page
+	return &next
+}
+
+// pageIter holds the fetch-next-page machinery shared by every Xxx Iter
+// type in this package. fetch retrieves one page starting from opts,
+// returning the page's items alongside the response's Link "next" URL (if
+// any, see apiResponse.LinkNext); pageIter honors it when present and
+// falls back to incrementing ListOptions.Page otherwise.
+type pageIter[T any] struct {
+	opts  *ListOptions
+	fetch func(ctx context.Context, opts *ListOptions) ([]T, string, error)
+
+	items []T
+	index int
+	err   error
+	done  bool
+}
+
+// newPageIter builds a pageIter that starts from opts and retrieves
+// successive pages via fetch.
+func newPageIter[T any](opts *ListOptions, fetch func(ctx context.Context, opts *ListOptions) ([]T, string, error)) *pageIter[T] {
+	return &pageIter[T]{opts: opts, fetch: fetch}
+}
+
+// Next advances the iterator, transparently fetching the next page of
+// results when the current page is exhausted. It returns false once
+// there are no more results or an error occurs; check Err to distinguish
+// the two.
+func (it *pageIter[T]) Next(ctx context.Context) bool {
+	if it.err != nil || it.done {
+		return false
+	}
+	if it.index < len(it.items) {
+		it.index++
+		return true
+	}
+
+	page, linkNext, err := it.fetch(ctx, it.opts)
+	if err != nil {
+		it.err = err
+		return false
+	}
+	if len(page) == 0 {
+		it.done = true
+		return false
+	}
+
+	it.items = page
+	it.index = 1
+	if linkNext != "" {
+		it.opts = it.opts.nextFromLink(linkNext)
+	} else {
+		it.opts = it.opts.nextPage()
+	}
+	return true
+}
+
+// Value returns the item most recently advanced to by Next.
+func (it *pageIter[T]) Value() T {
+	return it.items[it.index-1]
+}
+
+// Err returns the first error encountered during iteration, if any.
+func (it *pageIter[T]) Err() error {
+	return it.err
+}
+
+// ProductIter walks every page of a GetProductsInFamily-style result set,
+// fetching additional pages on demand as Next is called, honoring a
+// server-provided Link header when present and otherwise incrementing
+// ListOptions.Page.
+type ProductIter struct {
+	*pageIter[Product]
+}
+
+// Products returns a ProductIter over the products in familyID, honoring
+// opts.
+func (c *Client) Products(familyID int64, opts *ListOptions) *ProductIter {
+	return &ProductIter{newPageIter(opts, func(ctx context.Context, opts *ListOptions) ([]Product, string, error) {
+		params := opts.params()
+		params["familyID"] = fmt.Sprintf("%d", familyID)
+		ret, err := c.makeCall(ctx, endpoints[endpointProductGetForFamily], nil, &params)
+		if err != nil {
+			return nil, "", err
+		}
+
+		page := []Product{}
+		temp, ok := ret.Body.([]interface{})
+		if !ok {
+			return page, "", nil
+		}
+		for i := range temp {
+			entry, ok := temp[i].(map[string]interface{})
+			if !ok {
+				continue
+			}
+			product := Product{}
+			if err := decode(entry["product"], &product); err == nil {
+				page = append(page, product)
+			}
+		}
+		return page, ret.LinkNext, nil
+	})}
+}
+
+// CouponIter walks every page of a ListCoupons-style result set, honoring
+// a server-provided Link header when present and otherwise incrementing
+// ListOptions.Page.
+type CouponIter struct {
+	*pageIter[Coupon]
+}
+
+// Coupons returns a CouponIter over the coupons in familyID, honoring
+// opts.
+func (c *Client) Coupons(familyID int64, opts *ListOptions) *CouponIter {
+	return &CouponIter{newPageIter(opts, func(ctx context.Context, opts *ListOptions) ([]Coupon, string, error) {
+		params := opts.params()
+		params["familyID"] = fmt.Sprintf("%d", familyID)
+		ret, err := c.makeCall(ctx, endpoints[endpointCouponList], nil, &params)
+		if err != nil {
+			return nil, "", err
+		}
+
+		page := []Coupon{}
+		temp, ok := ret.Body.([]interface{})
+		if !ok {
+			return page, "", nil
+		}
+		for i := range temp {
+			entry, ok := temp[i].(map[string]interface{})
+			if !ok {
+				continue
+			}
+			coupon := Coupon{}
+			if err := decode(entry["coupon"], &coupon); err == nil {
+				page = append(page, coupon)
+			}
+		}
+		return page, ret.LinkNext, nil
+	})}
+}
+
+// ComponentIter walks every page of a ListComponents-style result set,
+// honoring a server-provided Link header when present and otherwise
+// incrementing ListOptions.Page.
+type ComponentIter struct {
+	*pageIter[Component]
+}
+
+// Components returns a ComponentIter over the components in familyID,
+// honoring opts.
+func (c *Client) Components(familyID int64, opts *ListOptions) *ComponentIter {
+	return &ComponentIter{newPageIter(opts, func(ctx context.Context, opts *ListOptions) ([]Component, string, error) {
+		params := opts.params()
+		params["familyID"] = fmt.Sprintf("%d", familyID)
+		ret, err := c.makeCall(ctx, endpoints[endpointComponentList], nil, &params)
+		if err != nil {
+			return nil, "", err
+		}
+
+		page := []Component{}
+		temp, ok := ret.Body.([]interface{})
+		if !ok {
+			return page, "", nil
+		}
+		for i := range temp {
+			entry, ok := temp[i].(map[string]interface{})
+			if !ok {
+				continue
+			}
+			comp := Component{}
+			if err := decode(entry["component"], &comp); err == nil {
+				page = append(page, comp)
+			}
+		}
+		return page, ret.LinkNext, nil
+	})}
+}
+
+// CustomerIter walks every page of a GetCustomers-style result set,
+// honoring a server-provided Link header when present and otherwise
+// incrementing ListOptions.Page. Customer is defined alongside the rest
+// of the customer API.
+type CustomerIter struct {
+	*pageIter[Customer]
+}
+
+// Customers returns a CustomerIter honoring opts.
+func (c *Client) Customers(opts *ListOptions) *CustomerIter {
+	return &CustomerIter{newPageIter(opts, func(ctx context.Context, opts *ListOptions) ([]Customer, string, error) {
+		params := opts.params()
+		ret, err := c.makeCall(ctx, endpoints[endpointCustomerList], nil, &params)
+		if err != nil {
+			return nil, "", err
+		}
+
+		page := []Customer{}
+		temp, ok := ret.Body.([]interface{})
+		if !ok {
+			return page, "", nil
+		}
+		for i := range temp {
+			entry, ok := temp[i].(map[string]interface{})
+			if !ok {
+				continue
+			}
+			customer := Customer{}
+			if err := decode(entry["customer"], &customer); err == nil {
+				page = append(page, customer)
+			}
+		}
+		return page, ret.LinkNext, nil
+	})}
+}
+
+// SubscriptionIter walks every page of a subscription list result set,
+// honoring a server-provided Link header when present and otherwise
+// incrementing ListOptions.Page. Subscription is defined alongside the
+// rest of the subscription API.
+type SubscriptionIter struct {
+	*pageIter[Subscription]
+}
+
+// Subscriptions returns a SubscriptionIter honoring opts.
+func (c *Client) Subscriptions(opts *ListOptions) *SubscriptionIter {
+	return &SubscriptionIter{newPageIter(opts, func(ctx context.Context, opts *ListOptions) ([]Subscription, string, error) {
+		params := opts.params()
+		ret, err := c.makeCall(ctx, endpoints[endpointSubscriptionList], nil, &params)
+		if err != nil {
+			return nil, "", err
+		}
+
+		page := []Subscription{}
+		temp, ok := ret.Body.([]interface{})
+		if !ok {
+			return page, "", nil
+		}
+		for i := range temp {
+			entry, ok := temp[i].(map[string]interface{})
+			if !ok {
+				continue
+			}
+			sub := Subscription{}
+			if err := decode(entry["subscription"], &sub); err == nil {
+				page = append(page, sub)
+			}
+		}
+		return page, ret.LinkNext, nil
+	})}
+}
@@ -0,0 +1,87 @@
+package chargify
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListOptionsNextFromLinkUsesPageQueryParam(t *testing.T) {
+	opts := &ListOptions{Page: 1, PerPage: 20}
+	next := opts.nextFromLink("https://acme.chargify.com/products.json?page=5&per_page=20")
+	assert.Equal(t, 5, next.Page)
+	assert.Equal(t, 20, next.PerPage)
+}
+
+func TestListOptionsNextFromLinkFallsBackWithoutPageParam(t *testing.T) {
+	opts := &ListOptions{Page: 2}
+	next := opts.nextFromLink("https://acme.chargify.com/products.json?per_page=20")
+	assert.Equal(t, 3, next.Page)
+}
+
+func TestListOptionsNextFromLinkFallsBackOnUnparsableURL(t *testing.T) {
+	opts := &ListOptions{Page: 2}
+	next := opts.nextFromLink("://not-a-url")
+	assert.Equal(t, 3, next.Page)
+}
+
+func TestListOptionsNextPageStartsAtTwoFromNil(t *testing.T) {
+	var opts *ListOptions
+	assert.Equal(t, 2, opts.nextPage().Page)
+}
+
+func TestPageIterStopsOnEmptyPage(t *testing.T) {
+	calls := 0
+	it := newPageIter(nil, func(ctx context.Context, opts *ListOptions) ([]int, string, error) {
+		calls++
+		if calls == 1 {
+			return []int{1, 2}, "", nil
+		}
+		return nil, "", nil
+	})
+
+	require.True(t, it.Next(context.Background()))
+	assert.Equal(t, 1, it.Value())
+	require.True(t, it.Next(context.Background()))
+	assert.Equal(t, 2, it.Value())
+
+	assert.False(t, it.Next(context.Background()))
+	assert.NoError(t, it.Err())
+	assert.Equal(t, 2, calls)
+
+	// Next returns false for good once the iterator is done, without
+	// re-invoking fetch.
+	assert.False(t, it.Next(context.Background()))
+	assert.Equal(t, 2, calls)
+}
+
+func TestPageIterStopsOnFetchError(t *testing.T) {
+	wantErr := errors.New("boom")
+	it := newPageIter(nil, func(ctx context.Context, opts *ListOptions) ([]int, string, error) {
+		return nil, "", wantErr
+	})
+
+	assert.False(t, it.Next(context.Background()))
+	assert.Equal(t, wantErr, it.Err())
+}
+
+func TestPageIterHonorsLinkNextOverPageIncrement(t *testing.T) {
+	var seenOpts []*ListOptions
+	it := newPageIter(&ListOptions{Page: 1}, func(ctx context.Context, opts *ListOptions) ([]int, string, error) {
+		seenOpts = append(seenOpts, opts)
+		switch len(seenOpts) {
+		case 1:
+			return []int{1}, "https://acme.chargify.com/x.json?page=9", nil
+		default:
+			return nil, "", nil
+		}
+	})
+
+	require.True(t, it.Next(context.Background()))
+	assert.False(t, it.Next(context.Background()))
+	require.Len(t, seenOpts, 2)
+	assert.Equal(t, 9, seenOpts[1].Page)
+}
@@ -0,0 +1,140 @@
+package chargify
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+
+	"github.com/shopspring/decimal"
+)
+
+// Money represents a monetary amount with sub-cent precision, paired with
+// an ISO-4217 currency code. It exists alongside the package's legacy
+// integer-cents fields (e.g. Product.PriceInCents) so that values like a
+// component's $0.0025-per-unit price can be represented without silently
+// truncating to the nearest cent.
+//
+// Money unmarshals from a wire-format integer number of cents (the format
+// used by most of Chargify's existing endpoints), a bare decimal string,
+// or a {"amount":...,"currency":...} object (used by newer endpoints
+// requiring high-precision unit prices), and always marshals back out as
+// a bare decimal string, matching the scalar format those newer endpoints
+// expect in a request body.
+type Money struct {
+	amount   decimal.Decimal
+	Currency string
+}
+
+// cents is the scale factor between a decimal.Decimal amount and its
+// integer-cents representation.
+var cents = decimal.New(100, 0)
+
+// NewMoney builds a Money value from a decimal amount (in the currency's
+// major unit, e.g. dollars) and an ISO-4217 currency code.
+func NewMoney(amount decimal.Decimal, currency string) Money {
+	return Money{amount: amount, Currency: currency}
+}
+
+// NewMoneyFromCents builds a Money value from an integer number of cents
+// and an ISO-4217 currency code.
+func NewMoneyFromCents(amountInCents int, currency string) Money {
+	return Money{amount: decimal.New(int64(amountInCents), 0).Div(cents), Currency: currency}
+}
+
+// Amount returns the underlying decimal amount, in the currency's major
+// unit.
+func (m Money) Amount() decimal.Decimal {
+	return m.amount
+}
+
+// Cents returns the amount rounded to the nearest integer cent, for
+// compatibility with the package's legacy integer-cents fields.
+func (m Money) Cents() int {
+	return int(m.amount.Mul(cents).Round(0).IntPart())
+}
+
+// String renders the amount as a decimal string, e.g. "19.99".
+func (m Money) String() string {
+	return m.amount.StringFixed(2)
+}
+
+// Add returns the sum of m and other. It does not check that the
+// currencies match; callers mixing currencies are responsible for
+// converting first.
+func (m Money) Add(other Money) Money {
+	return Money{amount: m.amount.Add(other.amount), Currency: m.Currency}
+}
+
+// Sub returns m minus other. It does not check that the currencies match.
+func (m Money) Sub(other Money) Money {
+	return Money{amount: m.amount.Sub(other.amount), Currency: m.Currency}
+}
+
+// Mul returns m scaled by factor, e.g. for computing a tiered unit price
+// times a quantity.
+func (m Money) Mul(factor decimal.Decimal) Money {
+	return Money{amount: m.amount.Mul(factor), Currency: m.Currency}
+}
+
+// moneyWireFormat is one of the object shapes Money accepts on unmarshal,
+// letting Currency be recovered from a response that carries it alongside
+// the amount.
+type moneyWireFormat struct {
+	Amount   string `json:"amount"`
+	Currency string `json:"currency,omitempty"`
+}
+
+// MarshalJSON renders the amount as a bare decimal string, e.g. "19.99".
+// Currency is not part of the marshaled form: Chargify's write endpoints
+// expect Money fields in a request body to be a scalar amount, not an
+// object, so Currency only round-trips on the unmarshal side, where the
+// response body already carries it.
+func (m Money) MarshalJSON() ([]byte, error) {
+	return json.Marshal(m.amount.String())
+}
+
+// UnmarshalJSON accepts a {"amount":...,"currency":...} object, an
+// integer number of cents (the wire format used by most existing
+// Chargify endpoints), or a bare decimal string amount (the form
+// MarshalJSON produces, also used by newer endpoints), so Money can be
+// dropped into either kind of response without a separate code path.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 || string(data) == "null" {
+		return nil
+	}
+
+	if data[0] == '{' {
+		var wire moneyWireFormat
+		if err := json.Unmarshal(data, &wire); err != nil {
+			return err
+		}
+		amount, err := decimal.NewFromString(wire.Amount)
+		if err != nil {
+			return errors.New("chargify: could not parse money amount " + wire.Amount)
+		}
+		m.amount = amount
+		m.Currency = wire.Currency
+		return nil
+	}
+
+	if data[0] == '"' {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		amount, err := decimal.NewFromString(s)
+		if err != nil {
+			return errors.New("chargify: could not parse money amount " + s)
+		}
+		m.amount = amount
+		return nil
+	}
+
+	var amountInCents int64
+	if err := json.Unmarshal(data, &amountInCents); err != nil {
+		return err
+	}
+	m.amount = decimal.New(amountInCents, 0).Div(cents)
+	return nil
+}
@@ -0,0 +1,64 @@
+package chargify
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMoneyCentsRoundTrip(t *testing.T) {
+	m := NewMoneyFromCents(1999, "USD")
+	assert.Equal(t, "19.99", m.String())
+	assert.Equal(t, 1999, m.Cents())
+	assert.Equal(t, "USD", m.Currency)
+}
+
+func TestMoneyArithmetic(t *testing.T) {
+	a := NewMoneyFromCents(1000, "USD")
+	b := NewMoneyFromCents(250, "USD")
+
+	assert.Equal(t, "12.50", a.Add(b).String())
+	assert.Equal(t, "7.50", a.Sub(b).String())
+	assert.Equal(t, "30.00", a.Mul(decimal.NewFromInt(3)).String())
+}
+
+func TestMoneyMarshalJSONIsBareScalar(t *testing.T) {
+	m := NewMoney(decimal.NewFromFloat(19.99), "USD")
+	out, err := json.Marshal(m)
+	require.NoError(t, err)
+	assert.Equal(t, `"19.99"`, string(out))
+}
+
+func TestMoneyUnmarshalJSONScalarString(t *testing.T) {
+	var m Money
+	require.NoError(t, json.Unmarshal([]byte(`"19.99"`), &m))
+	assert.Equal(t, "19.99", m.String())
+	assert.Empty(t, m.Currency)
+}
+
+func TestMoneyUnmarshalJSONIntegerCents(t *testing.T) {
+	var m Money
+	require.NoError(t, json.Unmarshal([]byte(`1999`), &m))
+	assert.Equal(t, "19.99", m.String())
+}
+
+func TestMoneyUnmarshalJSONObject(t *testing.T) {
+	var m Money
+	require.NoError(t, json.Unmarshal([]byte(`{"amount":"19.99","currency":"USD"}`), &m))
+	assert.Equal(t, "19.99", m.String())
+	assert.Equal(t, "USD", m.Currency)
+}
+
+func TestMoneyUnmarshalJSONNull(t *testing.T) {
+	var m Money
+	require.NoError(t, json.Unmarshal([]byte(`null`), &m))
+	assert.True(t, m.Amount().IsZero())
+}
+
+func TestMoneyUnmarshalJSONInvalidAmount(t *testing.T) {
+	var m Money
+	assert.Error(t, json.Unmarshal([]byte(`"not-a-number"`), &m))
+}
@@ -0,0 +1,148 @@
+package chargify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// PricePoint represents one pricing tier (e.g. monthly, annual, enterprise)
+// that can be attached to a Product, letting a single Product carry
+// multiple price points instead of forcing callers to create a separate
+// Product per tier.
+type PricePoint struct {
+	ID                      int64            `json:"id"`
+	Name                    string           `json:"name"`                           // The price point name
+	Handle                  string           `json:"handle"`                         // The price point API handle
+	PriceInCents            int              `json:"price_in_cents"`                 // The price, in integer cents
+	IntervalUnit            ProductInterval  `json:"interval_unit"`                  // A string representing the interval unit, either month or day
+	IntervalValue           int              `json:"interval"`                       // The numerical interval, coupled with IntervalUnit
+	TrialPriceInCents       *int             `json:"trial_price_in_cents,omitempty"` // The price of the trial period, in integer cents
+	TrialIntervalValue      *int             `json:"trial_interval,omitempty"`       // A numerical interval for the length of the trial period
+	TrialIntervalUnit       *ProductInterval `json:"trial_interval_unit,omitempty"`  // A string representing the trial interval unit, either month or day
+	InitialChargeInCents    int              `json:"initial_charge_in_cents"`        // The up front charge for this price point
+	ExpirationIntervalValue int              `json:"expiration_interval"`            // A numerical interval for how long a subscription on this price point will run before it expires
+	ExpirationIntervalUnit  ProductInterval  `json:"expiration_interval_unit"`       // A string representing the expiration interval unit, either month or day
+	Default                 bool             `json:"default"`                        // Whether this is the product's default price point
+	ArchivedAt              string           `json:"archived_at"`                    // Timestamp indicating when this price point was archived, if at all
+}
+
+// CreateProductPricePoint creates a new price point for the given product.
+func (c *Client) CreateProductPricePoint(ctx context.Context, productID int64, pp *PricePoint) error {
+	if pp.Name == "" || pp.Handle == "" {
+		return errors.New("name and handle are required")
+	}
+	if pp.IntervalUnit == "" || pp.IntervalValue == 0 {
+		return errors.New("interval and interval value must be provided")
+	}
+	body := map[string]PricePoint{
+		"price_point": *pp,
+	}
+
+	ret, err := c.makeCall(ctx, endpoints[endpointPricePointCreate], body, &map[string]string{
+		"productID": fmt.Sprintf("%d", productID),
+	})
+	if err != nil {
+		return err
+	}
+	apiBody, bodyOK := ret.Body.(map[string]interface{})
+	if !bodyOK {
+		return errors.New("could not understand server response")
+	}
+	return decode(apiBody["price_point"], pp)
+}
+
+// ListProductPricePoints lists every price point, including archived ones,
+// defined for the given product, honoring opts.
+func (c *Client) ListProductPricePoints(ctx context.Context, productID int64, opts *ListOptions) ([]PricePoint, error) {
+	pricePoints := []PricePoint{}
+	params := opts.params()
+	params["productID"] = fmt.Sprintf("%d", productID)
+	ret, err := c.makeCall(ctx, endpoints[endpointPricePointList], nil, &params)
+	if err != nil {
+		return nil, err
+	}
+
+	temp, ok := ret.Body.([]interface{})
+	if !ok {
+		return nil, errors.New("could not understand server response")
+	}
+	for i := range temp {
+		entry, ok := temp[i].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		pp := PricePoint{}
+		if err := decode(entry["price_point"], &pp); err == nil {
+			pricePoints = append(pricePoints, pp)
+		}
+	}
+	return pricePoints, nil
+}
+
+// UpdateProductPricePoint updates an existing price point.
+func (c *Client) UpdateProductPricePoint(ctx context.Context, productID, pricePointID int64, pp *PricePoint) error {
+	body := map[string]PricePoint{
+		"price_point": *pp,
+	}
+	_, err := c.makeCall(ctx, endpoints[endpointPricePointUpdate], body, &map[string]string{
+		"productID":    fmt.Sprintf("%d", productID),
+		"pricePointID": fmt.Sprintf("%d", pricePointID),
+	})
+	return err
+}
+
+// ArchiveProductPricePoint archives a price point. A product's default
+// price point cannot be archived.
+func (c *Client) ArchiveProductPricePoint(ctx context.Context, productID, pricePointID int64) error {
+	_, err := c.makeCall(ctx, endpoints[endpointPricePointArchive], nil, &map[string]string{
+		"productID":    fmt.Sprintf("%d", productID),
+		"pricePointID": fmt.Sprintf("%d", pricePointID),
+	})
+	return err
+}
+
+// PromoteProductPricePointToDefault makes the given price point the
+// product's default, used when no price point is specified at signup.
+func (c *Client) PromoteProductPricePointToDefault(ctx context.Context, productID, pricePointID int64) error {
+	_, err := c.makeCall(ctx, endpoints[endpointPricePointPromote], nil, &map[string]string{
+		"productID":    fmt.Sprintf("%d", productID),
+		"pricePointID": fmt.Sprintf("%d", pricePointID),
+	})
+	return err
+}
+
+// BulkCreateProductPricePoints creates several price points for a product
+// in a single request.
+func (c *Client) BulkCreateProductPricePoints(ctx context.Context, productID int64, pricePoints []PricePoint) ([]PricePoint, error) {
+	if len(pricePoints) == 0 {
+		return nil, errors.New("at least one price point is required")
+	}
+	body := map[string][]PricePoint{
+		"price_points": pricePoints,
+	}
+
+	ret, err := c.makeCall(ctx, endpoints[endpointPricePointBulkCreate], body, &map[string]string{
+		"productID": fmt.Sprintf("%d", productID),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	created := []PricePoint{}
+	temp, ok := ret.Body.([]interface{})
+	if !ok {
+		return nil, errors.New("could not understand server response")
+	}
+	for i := range temp {
+		entry, ok := temp[i].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		pp := PricePoint{}
+		if err := decode(entry["price_point"], &pp); err == nil {
+			created = append(created, pp)
+		}
+	}
+	return created, nil
+}
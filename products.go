@@ -1,12 +1,11 @@
 package chargify
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"math/rand"
 	"time"
-
-	"github.com/mitchellh/mapstructure"
 )
 
 // ProductInterval represents an interval used for various calculations in a product
@@ -22,7 +21,8 @@ var (
 // Product represents a single product
 type Product struct {
 	ID                      int64            `json:"id"`
-	PriceInCents            int              `json:"price_in_cents"`                 //	The product price, in integer cents
+	PriceInCents            int              `json:"price_in_cents"`                 //	The product price, in integer cents. Deprecated: prefer Price, which carries sub-cent precision; this field is kept populated during the deprecation window
+	Price                   *Money           `json:"price,omitempty"`                //	The product price with sub-cent precision. Mirrors PriceInCents during the deprecation window
 	Name                    string           `json:"name"`                           //	The product name
 	Handle                  string           `json:"handle"`                         //	The product API handle
 	Description             string           `json:"description"`                    //	The product description
@@ -46,6 +46,7 @@ type Product struct {
 	SignupPages             *[]SignupPage    `json:"public_signup_pages,omitempty"`  // An array of signup pages
 	AutoCreateSignupPage    bool             `json:"auto_create_signup_page"`        // Whether or not to create a signup page
 	TaxCode                 string           `json:"tax_code"`                       // A string representing the tax code related to the product type. This is especially important when using the Avalara service to tax based on locale. This attribute has a max length of 10 characters.
+	PricePoints             []PricePoint     `json:"price_points,omitempty"`         // The product's price points, populated when fetched with the price_points include
 }
 
 // SignupPage represents a product's signup page, if needed
@@ -66,7 +67,7 @@ type ProductFamily struct {
 }
 
 // CreateProductFamily creates a new product family
-func CreateProductFamily(name, description, handle string, accountingCode string) (*ProductFamily, error) {
+func (c *Client) CreateProductFamily(ctx context.Context, name, description, handle string, accountingCode string) (*ProductFamily, error) {
 	family := &ProductFamily{
 		Name:           name,
 		Description:    description,
@@ -80,7 +81,7 @@ func CreateProductFamily(name, description, handle string, accountingCode string
 		"product_family": *family,
 	}
 
-	ret, err := makeCall(endpoints[endpointProductFamilyCreate], body, nil)
+	ret, err := c.makeCall(ctx, endpoints[endpointProductFamilyCreate], body, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -89,14 +90,14 @@ func CreateProductFamily(name, description, handle string, accountingCode string
 	if !bodyOK {
 		return nil, errors.New("could not understand server response")
 	}
-	err = mapstructure.Decode(apiBody["product_family"], family)
+	err = decode(apiBody["product_family"], family)
 	return family, err
 }
 
 // GetProductFamily gets a product family
-func GetProductFamily(productFamilyID int64) (*ProductFamily, error) {
+func (c *Client) GetProductFamily(ctx context.Context, productFamilyID int64) (*ProductFamily, error) {
 	family := &ProductFamily{}
-	ret, err := makeCall(endpoints[endpointProductFamilyGet], nil, &map[string]string{
+	ret, err := c.makeCall(ctx, endpoints[endpointProductFamilyGet], nil, &map[string]string{
 		"id": fmt.Sprintf("%d", productFamilyID),
 	})
 	if err != nil {
@@ -106,12 +107,12 @@ func GetProductFamily(productFamilyID int64) (*ProductFamily, error) {
 	if !bodyOK {
 		return nil, errors.New("could not understand server response")
 	}
-	err = mapstructure.Decode(apiBody["product_family"], family)
+	err = decode(apiBody["product_family"], family)
 	return family, err
 }
 
 // CreateProduct creates a new product and places the result in the input
-func CreateProduct(productFamilyID int64, input *Product) error {
+func (c *Client) CreateProduct(ctx context.Context, productFamilyID int64, input *Product) error {
 	if input.Name == "" || input.Handle == "" || input.Description == "" {
 		return errors.New("name, handle, and description are required")
 	}
@@ -125,7 +126,7 @@ func CreateProduct(productFamilyID int64, input *Product) error {
 		"product": *input,
 	}
 
-	ret, err := makeCall(endpoints[endpointProductCreate], body, &map[string]string{
+	ret, err := c.makeCall(ctx, endpoints[endpointProductCreate], body, &map[string]string{
 		"familyID": fmt.Sprintf("%d", productFamilyID),
 	})
 	if err != nil {
@@ -136,16 +137,22 @@ func CreateProduct(productFamilyID int64, input *Product) error {
 	if !bodyOK {
 		return errors.New("could not understand server response")
 	}
-	err = mapstructure.Decode(apiBody["product"], input)
+	err = decode(apiBody["product"], input)
 	return err
 }
 
-// GetProductByID gets a single product by id
-func GetProductByID(productID int64) (*Product, error) {
+// GetProductByID gets a single product by id. When includePricePoints is
+// true, the request is made with include=price_points so the returned
+// Product's PricePoints field is populated.
+func (c *Client) GetProductByID(ctx context.Context, productID int64, includePricePoints bool) (*Product, error) {
 	product := &Product{}
-	ret, err := makeCall(endpoints[endpointProductGetByID], nil, &map[string]string{
+	params := &map[string]string{
 		"id": fmt.Sprintf("%d", productID),
-	})
+	}
+	if includePricePoints {
+		(*params)["include"] = "price_points"
+	}
+	ret, err := c.makeCall(ctx, endpoints[endpointProductGetByID], nil, params)
 	if err != nil {
 		return nil, err
 	}
@@ -153,14 +160,14 @@ func GetProductByID(productID int64) (*Product, error) {
 	if !bodyOK {
 		return nil, errors.New("could not understand server response")
 	}
-	err = mapstructure.Decode(apiBody["product"], product)
+	err = decode(apiBody["product"], product)
 	return product, err
 }
 
 // GetProductsInFamily gets all of the products in a family
-func GetProductsInFamily(productFamilyID int64) ([]Product, error) {
+func (c *Client) GetProductsInFamily(ctx context.Context, productFamilyID int64) ([]Product, error) {
 	products := []Product{}
-	ret, err := makeCall(endpoints[endpointProductGetForFamily], nil, &map[string]string{
+	ret, err := c.makeCall(ctx, endpoints[endpointProductGetForFamily], nil, &map[string]string{
 		"familyID": fmt.Sprintf("%d", productFamilyID),
 	})
 	if err != nil {
@@ -168,13 +175,18 @@ func GetProductsInFamily(productFamilyID int64) ([]Product, error) {
 	}
 
 	// so, Chargify violates OWASP best practices by returning these in an array
-	temp := ret.Body.([]interface{})
+	temp, ok := ret.Body.([]interface{})
+	if !ok {
+		return nil, errors.New("could not understand server response")
+	}
 	for i := range temp {
-		entry := temp[i].(map[string]interface{})
+		entry, ok := temp[i].(map[string]interface{})
+		if !ok {
+			continue
+		}
 		raw := entry["product"]
 		product := Product{}
-		err = mapstructure.Decode(raw, &product)
-		if err == nil {
+		if err := decode(raw, &product); err == nil {
 			products = append(products, product)
 		}
 	}
@@ -182,9 +194,9 @@ func GetProductsInFamily(productFamilyID int64) ([]Product, error) {
 }
 
 // GetProductByHandle gets a product by its handle
-func GetProductByHandle(handle string) (*Product, error) {
+func (c *Client) GetProductByHandle(ctx context.Context, handle string) (*Product, error) {
 	product := &Product{}
-	ret, err := makeCall(endpoints[endpointProductGetByHandle], nil, &map[string]string{
+	ret, err := c.makeCall(ctx, endpoints[endpointProductGetByHandle], nil, &map[string]string{
 		"handle": handle,
 	})
 	if err != nil {
@@ -194,30 +206,136 @@ func GetProductByHandle(handle string) (*Product, error) {
 	if !bodyOK {
 		return nil, errors.New("could not understand server response")
 	}
-	err = mapstructure.Decode(apiBody["product"], product)
+	err = decode(apiBody["product"], product)
 	return product, err
 }
 
 // UpdateProduct updates a product
-func UpdateProduct(productID int64, input *Product) error {
+func (c *Client) UpdateProduct(ctx context.Context, productID int64, input *Product) error {
 	body := map[string]Product{
 		"product": *input,
 	}
 
-	_, err := makeCall(endpoints[endpointProductUpdate], body, &map[string]string{
+	_, err := c.makeCall(ctx, endpoints[endpointProductUpdate], body, &map[string]string{
 		"productID": fmt.Sprintf("%d", productID),
 	})
 	return err
 }
 
 // ArchiveProduct archives a product
-func ArchiveProduct(productID int64) error {
-	_, err := makeCall(endpoints[endpointProductArchive], nil, &map[string]string{
+func (c *Client) ArchiveProduct(ctx context.Context, productID int64) error {
+	_, err := c.makeCall(ctx, endpoints[endpointProductArchive], nil, &map[string]string{
 		"id": fmt.Sprintf("%d", productID),
 	})
 	return err
 }
 
+// CreateProductFamily creates a new product family using DefaultClient.
+//
+// Deprecated: construct a *Client with NewClient and call
+// Client.CreateProductFamily instead. This wrapper will be removed in a
+// future release.
+func CreateProductFamily(name, description, handle string, accountingCode string) (*ProductFamily, error) {
+	c, err := defaultClient()
+	if err != nil {
+		return nil, err
+	}
+	return c.CreateProductFamily(context.Background(), name, description, handle, accountingCode)
+}
+
+// GetProductFamily gets a product family using DefaultClient.
+//
+// Deprecated: construct a *Client with NewClient and call
+// Client.GetProductFamily instead. This wrapper will be removed in a
+// future release.
+func GetProductFamily(productFamilyID int64) (*ProductFamily, error) {
+	c, err := defaultClient()
+	if err != nil {
+		return nil, err
+	}
+	return c.GetProductFamily(context.Background(), productFamilyID)
+}
+
+// CreateProduct creates a new product and places the result in the input
+// using DefaultClient.
+//
+// Deprecated: construct a *Client with NewClient and call
+// Client.CreateProduct instead. This wrapper will be removed in a future
+// release.
+func CreateProduct(productFamilyID int64, input *Product) error {
+	c, err := defaultClient()
+	if err != nil {
+		return err
+	}
+	return c.CreateProduct(context.Background(), productFamilyID, input)
+}
+
+// GetProductByID gets a single product by id using DefaultClient.
+//
+// Deprecated: construct a *Client with NewClient and call
+// Client.GetProductByID instead. This wrapper will be removed in a future
+// release.
+func GetProductByID(productID int64) (*Product, error) {
+	c, err := defaultClient()
+	if err != nil {
+		return nil, err
+	}
+	return c.GetProductByID(context.Background(), productID, false)
+}
+
+// GetProductsInFamily gets all of the products in a family using
+// DefaultClient.
+//
+// Deprecated: construct a *Client with NewClient and call
+// Client.GetProductsInFamily instead. This wrapper will be removed in a
+// future release.
+func GetProductsInFamily(productFamilyID int64) ([]Product, error) {
+	c, err := defaultClient()
+	if err != nil {
+		return nil, err
+	}
+	return c.GetProductsInFamily(context.Background(), productFamilyID)
+}
+
+// GetProductByHandle gets a product by its handle using DefaultClient.
+//
+// Deprecated: construct a *Client with NewClient and call
+// Client.GetProductByHandle instead. This wrapper will be removed in a
+// future release.
+func GetProductByHandle(handle string) (*Product, error) {
+	c, err := defaultClient()
+	if err != nil {
+		return nil, err
+	}
+	return c.GetProductByHandle(context.Background(), handle)
+}
+
+// UpdateProduct updates a product using DefaultClient.
+//
+// Deprecated: construct a *Client with NewClient and call
+// Client.UpdateProduct instead. This wrapper will be removed in a future
+// release.
+func UpdateProduct(productID int64, input *Product) error {
+	c, err := defaultClient()
+	if err != nil {
+		return err
+	}
+	return c.UpdateProduct(context.Background(), productID, input)
+}
+
+// ArchiveProduct archives a product using DefaultClient.
+//
+// Deprecated: construct a *Client with NewClient and call
+// Client.ArchiveProduct instead. This wrapper will be removed in a future
+// release.
+func ArchiveProduct(productID int64) error {
+	c, err := defaultClient()
+	if err != nil {
+		return err
+	}
+	return c.ArchiveProduct(context.Background(), productID)
+}
+
 func createTestProductAndFamily() (*ProductFamily, *Product, error) {
 	rand.Seed(time.Now().UnixNano())
 	randID := rand.Int63()
@@ -240,4 +358,4 @@ func createTestProductAndFamily() (*ProductFamily, *Product, error) {
 	}
 	err = CreateProduct(family.ID, product)
 	return family, product, err
-}
\ No newline at end of file
+}
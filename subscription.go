@@ -0,0 +1,12 @@
+package chargify
+
+// Subscription represents a single subscription record. It is minimal for
+// now, providing just enough fields for the coupon, component, and
+// iterator APIs that reference a subscription by id; a full subscription
+// CRUD surface is tracked separately.
+type Subscription struct {
+	ID         int64  `json:"id"`
+	State      string `json:"state"`       // The subscription's current state, e.g. "active", "trialing", "canceled"
+	CustomerID int64  `json:"customer_id"` // The customer this subscription belongs to
+	ProductID  int64  `json:"product_id"`  // The product this subscription is on
+}
@@ -0,0 +1,167 @@
+package chargify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// apiResponse is the result of a single successful API call. Body holds
+// the decoded JSON response (typically a map[string]interface{} or
+// []interface{}), except for endpoints marked Raw, where it holds an
+// io.ReadCloser over the unparsed response body.
+type apiResponse struct {
+	StatusCode     int
+	Body           interface{}
+	IdempotencyKey string
+
+	// LinkNext is the "next" relation URL from the response's RFC 5988
+	// Link header, if any. List endpoints that paginate by cursor rather
+	// than by page number surface it here instead of (or alongside) a
+	// conventional page number.
+	LinkNext string
+}
+
+// apiStatusError is returned when the API responds with a non-2xx status.
+type apiStatusError struct {
+	StatusCode int
+	RetryAfter string
+	Message    string
+}
+
+func (e *apiStatusError) Error() string {
+	return fmt.Sprintf("chargify: request failed with status %d: %s", e.StatusCode, e.Message)
+}
+
+// doAPICall substitutes endpoint.PathTemplate's "{name}" placeholders
+// from params, sends any remaining params as a query string, and issues
+// the request against c's configured base URL using HTTP Basic Auth.
+// idempotencyKey, when non-empty, is sent as the Idempotency-Key header.
+func doAPICall(ctx context.Context, c *Client, endpoint endpointSpec, body interface{}, params *map[string]string, idempotencyKey string) (*apiResponse, error) {
+	if c.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+
+	path, query := renderPath(endpoint.PathTemplate, params)
+
+	reqURL := c.baseURL + path
+	if len(query) > 0 {
+		reqURL += "?" + query.Encode()
+	}
+
+	var bodyReader io.Reader
+	if body != nil {
+		encoded, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		bodyReader = bytes.NewReader(encoded)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, endpoint.HTTPMethod, reqURL, bodyReader)
+	if err != nil {
+		return nil, err
+	}
+	req.SetBasicAuth(c.apiKey, "x")
+	if endpoint.Raw {
+		req.Header.Set("Accept", "*/*")
+	} else {
+		req.Header.Set("Accept", "application/json")
+	}
+	if bodyReader != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if idempotencyKey != "" && isMutatingMethod(endpoint.HTTPMethod) {
+		req.Header.Set(idempotencyKeyHeader, idempotencyKey)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode >= 300 {
+		defer resp.Body.Close()
+		raw, _ := io.ReadAll(resp.Body)
+		return nil, &apiStatusError{
+			StatusCode: resp.StatusCode,
+			RetryAfter: resp.Header.Get("Retry-After"),
+			Message:    string(raw),
+		}
+	}
+
+	linkNext := parseLinkNext(resp.Header.Get("Link"))
+
+	defer resp.Body.Close()
+
+	if endpoint.Raw {
+		// The request context is cancelled when doAPICall returns, which
+		// would abort any later read of resp.Body, so the raw body is read
+		// in full here.
+		raw, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, err
+		}
+		return &apiResponse{StatusCode: resp.StatusCode, Body: io.NopCloser(bytes.NewReader(raw)), LinkNext: linkNext}, nil
+	}
+
+	if resp.StatusCode == http.StatusNoContent {
+		return &apiResponse{StatusCode: resp.StatusCode, LinkNext: linkNext}, nil
+	}
+
+	var decoded interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&decoded); err != nil && err != io.EOF {
+		return nil, err
+	}
+	return &apiResponse{StatusCode: resp.StatusCode, Body: decoded, LinkNext: linkNext}, nil
+}
+
+// parseLinkNext extracts the "next" relation URL from an RFC 5988 Link
+// header, e.g. `<https://x.chargify.com/invoices?page=2>; rel="next"`,
+// returning "" if header is empty or has no "next" relation.
+func parseLinkNext(header string) string {
+	for _, part := range strings.Split(header, ",") {
+		segments := strings.Split(part, ";")
+		if len(segments) < 2 {
+			continue
+		}
+		url := strings.TrimSpace(segments[0])
+		url = strings.TrimPrefix(url, "<")
+		url = strings.TrimSuffix(url, ">")
+		for _, attr := range segments[1:] {
+			attr = strings.TrimSpace(attr)
+			if attr == `rel="next"` || attr == "rel=next" {
+				return url
+			}
+		}
+	}
+	return ""
+}
+
+// renderPath substitutes every "{name}" placeholder in template from
+// params, percent-encoding each value, and returns the remaining,
+// unconsumed params as query values.
+func renderPath(template string, params *map[string]string) (string, url.Values) {
+	query := url.Values{}
+	if params == nil {
+		return template, query
+	}
+
+	path := template
+	for key, value := range *params {
+		placeholder := "{" + key + "}"
+		if strings.Contains(path, placeholder) {
+			path = strings.ReplaceAll(path, placeholder, url.PathEscape(value))
+			continue
+		}
+		query.Set(key, value)
+	}
+	return path, query
+}
@@ -0,0 +1,299 @@
+package chargify
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// signatureHeader is the header Chargify sets with the HMAC-SHA256
+// signature of the raw request body, keyed by the webhook's shared
+// secret.
+const signatureHeader = "X-Chargify-Webhook-Signature-Hmac-Sha256"
+
+// webhookIDHeader identifies a single webhook delivery, used as the
+// EventStore de-duplication key.
+const webhookIDHeader = "X-Chargify-Webhook-Id"
+
+// SubscriptionStateChangedEvent is delivered on the subscription_state_change
+// webhook event.
+type SubscriptionStateChangedEvent struct {
+	SubscriptionID int64  `json:"subscription_id"`
+	PreviousState  string `json:"previous_state"`
+	CurrentState   string `json:"state"`
+}
+
+// PaymentSuccessEvent is delivered on the payment_success webhook event.
+type PaymentSuccessEvent struct {
+	SubscriptionID int64 `json:"subscription_id"`
+	TransactionID  int64 `json:"transaction_id"`
+	AmountInCents  int   `json:"amount_in_cents"`
+}
+
+// RenewalSuccessEvent is delivered on the renewal_success webhook event.
+type RenewalSuccessEvent struct {
+	SubscriptionID int64 `json:"subscription_id"`
+}
+
+// ComponentAllocationChangeEvent is delivered on the
+// component_allocation_change webhook event.
+type ComponentAllocationChangeEvent struct {
+	SubscriptionID int64 `json:"subscription_id"`
+	ComponentID    int64 `json:"component_id"`
+	PreviousValue  int   `json:"previous_value"`
+	NewValue       int   `json:"new_value"`
+}
+
+// EventStore lets callers guarantee at-most-once processing of webhook
+// deliveries, keyed by the X-Chargify-Webhook-Id header, by backing it
+// with Redis, Postgres, or any other durable store.
+type EventStore interface {
+	// SeenBefore records webhookID as processed and reports whether it
+	// had already been recorded, so the caller can skip reprocessing a
+	// redelivered webhook.
+	SeenBefore(ctx context.Context, webhookID string) (bool, error)
+}
+
+// WebhookHandler verifies and dispatches incoming Chargify webhook
+// deliveries. At least one On* handler should be set; events without a
+// matching handler are ignored.
+type WebhookHandler struct {
+	Secret          string        // The shared secret configured for this webhook endpoint in Chargify
+	FreshnessWindow time.Duration // Deliveries older than this are rejected. Zero disables the freshness check
+	Store           EventStore    // Optional de-duplication store, keyed by webhook id
+
+	OnSubscriptionStateChange   func(ctx context.Context, ev SubscriptionStateChangedEvent) error
+	OnPaymentSuccess            func(ctx context.Context, ev PaymentSuccessEvent) error
+	OnRenewalSuccess            func(ctx context.Context, ev RenewalSuccessEvent) error
+	OnComponentAllocationChange func(ctx context.Context, ev ComponentAllocationChangeEvent) error
+}
+
+// ServeHTTP implements http.Handler, verifying the webhook's HMAC
+// signature, checking its freshness and (if Store is set) its
+// de-duplication id, then dispatching the parsed event to the matching
+// On* handler.
+func (h *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, "could not read request body", http.StatusBadRequest)
+		return
+	}
+	// ParseForm below needs to read the body again; io.ReadAll has
+	// already consumed it, so restore a fresh reader over the bytes we
+	// captured for signature verification.
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	if !h.verifySignature(r, body) {
+		http.Error(w, "invalid webhook signature", http.StatusUnauthorized)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "could not parse webhook form body", http.StatusBadRequest)
+		return
+	}
+
+	if h.FreshnessWindow > 0 {
+		if err := h.checkFreshness(r); err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+	}
+
+	if h.Store != nil {
+		webhookID := r.Header.Get(webhookIDHeader)
+		seen, err := h.Store.SeenBefore(r.Context(), webhookID)
+		if err != nil {
+			http.Error(w, "could not check event store", http.StatusInternalServerError)
+			return
+		}
+		if seen {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+	}
+
+	if err := h.dispatch(r); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// verifySignature recomputes HMAC-SHA256(secret, body) and compares it
+// against the signature header using a constant-time comparison.
+func (h *WebhookHandler) verifySignature(r *http.Request, body []byte) bool {
+	want := r.Header.Get(signatureHeader)
+	if want == "" {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, []byte(h.Secret))
+	mac.Write(body)
+	got := hex.EncodeToString(mac.Sum(nil))
+
+	return hmac.Equal([]byte(got), []byte(want))
+}
+
+// checkFreshness rejects deliveries older than FreshnessWindow, measured
+// from the "created_at" form field Chargify sends alongside the payload.
+// (X-Chargify-Webhook-Id is a plain sequential integer, not a timestamp,
+// so it cannot be used to infer delivery age.)
+func (h *WebhookHandler) checkFreshness(r *http.Request) error {
+	createdAt := r.FormValue("created_at")
+	if createdAt == "" {
+		return errors.New("missing created_at field")
+	}
+	deliveredAt, err := time.Parse(time.RFC3339, createdAt)
+	if err != nil {
+		return fmt.Errorf("could not parse created_at: %w", err)
+	}
+	if time.Since(deliveredAt) > h.FreshnessWindow {
+		return errors.New("webhook delivery is stale")
+	}
+	return nil
+}
+
+// dispatch selects the typed event for r's "event" form field, decodes its
+// "payload[...]" fields into that event, and invokes the matching
+// handler.
+func (h *WebhookHandler) dispatch(r *http.Request) error {
+	ctx := r.Context()
+	eventType := r.FormValue("event")
+	payload := formPayload(r)
+
+	switch eventType {
+	case "subscription_state_change":
+		if h.OnSubscriptionStateChange == nil {
+			return nil
+		}
+		ev := SubscriptionStateChangedEvent{}
+		if err := decodeForm(payload, &ev); err != nil {
+			return err
+		}
+		return h.OnSubscriptionStateChange(ctx, ev)
+	case "payment_success":
+		if h.OnPaymentSuccess == nil {
+			return nil
+		}
+		ev := PaymentSuccessEvent{}
+		if err := decodeForm(payload, &ev); err != nil {
+			return err
+		}
+		return h.OnPaymentSuccess(ctx, ev)
+	case "renewal_success":
+		if h.OnRenewalSuccess == nil {
+			return nil
+		}
+		ev := RenewalSuccessEvent{}
+		if err := decodeForm(payload, &ev); err != nil {
+			return err
+		}
+		return h.OnRenewalSuccess(ctx, ev)
+	case "component_allocation_change":
+		if h.OnComponentAllocationChange == nil {
+			return nil
+		}
+		ev := ComponentAllocationChangeEvent{}
+		if err := decodeForm(payload, &ev); err != nil {
+			return err
+		}
+		return h.OnComponentAllocationChange(ctx, ev)
+	default:
+		return nil
+	}
+}
+
+// formPayload collects every "payload[key]" form field into a flat map
+// keyed by key, as Chargify posts webhook payloads form-encoded.
+func formPayload(r *http.Request) map[string]interface{} {
+	payload := map[string]interface{}{}
+	for key, values := range r.Form {
+		if len(values) == 0 {
+			continue
+		}
+		if len(key) > len("payload[") && key[:len("payload[")] == "payload[" && key[len(key)-1] == ']' {
+			payload[key[len("payload["):len(key)-1]] = values[0]
+		}
+	}
+	return payload
+}
+
+// WebhookEndpoint describes a webhook subscription configured on the
+// Chargify side.
+type WebhookEndpoint struct {
+	ID     int64    `json:"id"`
+	URL    string   `json:"url"`              // The URL deliveries are POSTed to
+	Events []string `json:"events,omitempty"` // The event types this endpoint is subscribed to. Empty means all events
+}
+
+// WebhooksClient manages webhook endpoints and replays past deliveries
+// via the Chargify API.
+type WebhooksClient struct {
+	client *Client
+}
+
+// Webhooks returns a WebhooksClient scoped to this Client's configured
+// site.
+func (c *Client) Webhooks() *WebhooksClient {
+	return &WebhooksClient{client: c}
+}
+
+// CreateEndpoint registers a new webhook endpoint.
+func (wc *WebhooksClient) CreateEndpoint(ctx context.Context, endpoint *WebhookEndpoint) error {
+	if endpoint.URL == "" {
+		return errors.New("url is required")
+	}
+	body := map[string]WebhookEndpoint{
+		"endpoint": *endpoint,
+	}
+	ret, err := wc.client.makeCall(ctx, endpoints[endpointWebhookEndpointCreate], body, nil)
+	if err != nil {
+		return err
+	}
+	apiBody, bodyOK := ret.Body.(map[string]interface{})
+	if !bodyOK {
+		return errors.New("could not understand server response")
+	}
+	return decode(apiBody["endpoint"], endpoint)
+}
+
+// ListEndpoints lists every webhook endpoint configured on the site.
+func (wc *WebhooksClient) ListEndpoints(ctx context.Context) ([]WebhookEndpoint, error) {
+	endpointsList := []WebhookEndpoint{}
+	ret, err := wc.client.makeCall(ctx, endpoints[endpointWebhookEndpointList], nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	temp, ok := ret.Body.([]interface{})
+	if !ok {
+		return nil, errors.New("could not understand server response")
+	}
+	for i := range temp {
+		entry, ok := temp[i].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		ep := WebhookEndpoint{}
+		if err := decode(entry["endpoint"], &ep); err == nil {
+			endpointsList = append(endpointsList, ep)
+		}
+	}
+	return endpointsList, nil
+}
+
+// ReplayWebhook asks Chargify to redeliver a past webhook by its id.
+func (wc *WebhooksClient) ReplayWebhook(ctx context.Context, webhookID string) error {
+	_, err := wc.client.makeCall(ctx, endpoints[endpointWebhookReplay], nil, &map[string]string{
+		"id": webhookID,
+	})
+	return err
+}
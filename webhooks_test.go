@@ -0,0 +1,86 @@
+package chargify
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func signedWebhookRequest(t *testing.T, secret string, form url.Values) *http.Request {
+	t.Helper()
+	body := form.Encode()
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(body))
+	signature := hex.EncodeToString(mac.Sum(nil))
+
+	req := httptest.NewRequest(http.MethodPost, "/webhooks", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set(signatureHeader, signature)
+	req.Header.Set(webhookIDHeader, "48212345")
+	return req
+}
+
+func TestServeHTTPDispatchesFormEncodedSubscriptionStateChange(t *testing.T) {
+	secret := "s3cr3t"
+	form := url.Values{
+		"event":                    {"subscription_state_change"},
+		"created_at":               {time.Now().Format(time.RFC3339)},
+		"payload[subscription_id]": {"12345"},
+		"payload[previous_state]":  {"trialing"},
+		"payload[state]":           {"active"},
+	}
+	req := signedWebhookRequest(t, secret, form)
+
+	var got SubscriptionStateChangedEvent
+	handler := &WebhookHandler{
+		Secret:          secret,
+		FreshnessWindow: time.Hour,
+		OnSubscriptionStateChange: func(ctx context.Context, ev SubscriptionStateChangedEvent) error {
+			got = ev
+			return nil
+		},
+	}
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, int64(12345), got.SubscriptionID)
+	assert.Equal(t, "trialing", got.PreviousState)
+	assert.Equal(t, "active", got.CurrentState)
+}
+
+func TestServeHTTPRejectsStaleDelivery(t *testing.T) {
+	secret := "s3cr3t"
+	form := url.Values{
+		"event":                    {"renewal_success"},
+		"created_at":               {time.Now().Add(-2 * time.Hour).Format(time.RFC3339)},
+		"payload[subscription_id]": {"12345"},
+	}
+	req := signedWebhookRequest(t, secret, form)
+
+	handler := &WebhookHandler{
+		Secret:          secret,
+		FreshnessWindow: time.Hour,
+		OnRenewalSuccess: func(ctx context.Context, ev RenewalSuccessEvent) error {
+			t.Fatal("handler should not be invoked for a stale delivery")
+			return nil
+		},
+	}
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}